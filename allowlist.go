@@ -0,0 +1,100 @@
+package log
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// allowlist is the state shared by every core of an allowlist logger.
+type allowlist struct {
+	allowed map[string]struct{}
+	warned  sync.Map // Keys already reported as dropped.
+}
+
+// allowlistCore wraps a zapcore.Core and removes fields whose keys are not allowlisted.
+type allowlistCore struct {
+	zapcore.Core
+	list    *allowlist
+	dropped []string // Context keys dropped by With, reported when an entry is written.
+}
+
+// NewAllowlistLogger returns a logger that strips every field whose key is not in allowed, from
+// both per-call and context fields. The first time a key is dropped from a written entry, a
+// warning naming it is logged. Fields attached to base before the call are kept. Loggers not
+// built by this package are returned unchanged.
+func NewAllowlistLogger(base Logger, allowed []string) Logger {
+	l, ok := base.(*zapLogger)
+	if !ok {
+		return base
+	}
+
+	list := &allowlist{allowed: make(map[string]struct{}, len(allowed))}
+	for _, key := range allowed {
+		list.allowed[key] = struct{}{}
+	}
+	return l.wrapCore(func(core zapcore.Core) zapcore.Core {
+		return &allowlistCore{Core: core, list: list}
+	})
+}
+
+// With filters the context fields before handing them to the wrapped core. Dropped keys are
+// reported by Write, so deriving a logger that never writes logs nothing.
+func (c *allowlistCore) With(fields []zapcore.Field) zapcore.Core {
+	kept, dropped := c.filter(fields)
+	return &allowlistCore{
+		Core:    c.Core.With(kept),
+		list:    c.list,
+		dropped: append(c.dropped[:len(c.dropped):len(c.dropped)], dropped...),
+	}
+}
+
+// Check registers the allowlist core for entries the wrapped core would accept.
+func (c *allowlistCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return checkWrapped(c.Core, c, ent, ce)
+}
+
+// Write filters the entry fields, reports the dropped keys and passes the entry to the wrapped core.
+func (c *allowlistCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	kept, dropped := c.filter(fields)
+	c.warnDropped(ent.Time, c.dropped)
+	c.warnDropped(ent.Time, dropped)
+	return c.Core.Write(ent, kept)
+}
+
+// filter returns the allowlisted fields, copying only when a field is dropped, and the dropped keys.
+func (c *allowlistCore) filter(fields []zapcore.Field) ([]zapcore.Field, []string) {
+	for i, f := range fields {
+		if _, ok := c.list.allowed[f.Key]; ok {
+			continue
+		}
+		kept := append([]zapcore.Field(nil), fields[:i]...)
+		dropped := []string{f.Key}
+		for _, f := range fields[i+1:] {
+			if _, ok := c.list.allowed[f.Key]; ok {
+				kept = append(kept, f)
+			} else {
+				dropped = append(dropped, f.Key)
+			}
+		}
+		return kept, dropped
+	}
+	return fields, nil
+}
+
+// warnDropped logs a warning for each key the first time it is dropped, timestamped at now, the
+// time of the entry it was dropped from, so the warning follows Config.Clock.
+func (c *allowlistCore) warnDropped(now time.Time, keys []string) {
+	if len(keys) == 0 || !c.Core.Enabled(zapcore.WarnLevel) {
+		return
+	}
+	for _, key := range keys {
+		if _, seen := c.list.warned.LoadOrStore(key, struct{}{}); seen {
+			continue
+		}
+		ent := zapcore.Entry{Level: zapcore.WarnLevel, Time: now, Message: "dropped field not on the allowlist"}
+		_ = c.Core.Write(ent, []zapcore.Field{zap.String("field", key)})
+	}
+}
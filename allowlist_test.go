@@ -0,0 +1,63 @@
+package log
+
+import (
+	"testing"
+	"time"
+)
+
+// Test NewAllowlistLogger to verify disallowed fields are removed, allowed ones kept and a warning logged once
+func TestNewAllowlistLogger(t *testing.T) {
+	base, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	logger := NewAllowlistLogger(base, []string{"user", "status"})
+	for i := 0; i < 3; i++ {
+		logger.WithField("session", "unused")
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("Expected no warning for loggers that never write, got %s", buf.String())
+	}
+	logger.WithField("session", "s1").Infow("first", "user", "bob", "status", 200, "debug_blob", "x")
+	logger.Infow("second", "debug_blob", "y", "user", "eve")
+
+	var entries []map[string]interface{}
+	warnings := map[interface{}]int{}
+	for _, entry := range decodeEntries(t, buf.String()) {
+		if entry["message"] == "dropped field not on the allowlist" {
+			warnings[entry["field"]]++
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %v", entries)
+	}
+	if entries[0]["user"] != "bob" || entries[0]["status"] != float64(200) || entries[1]["user"] != "eve" {
+		t.Errorf("Expected allowed fields to remain, got %v", entries)
+	}
+	for _, entry := range entries {
+		for _, key := range []string{"session", "debug_blob"} {
+			if _, ok := entry[key]; ok {
+				t.Errorf("Expected %q to be dropped, got %v", key, entry)
+			}
+		}
+	}
+	if warnings["session"] != 1 || warnings["debug_blob"] != 1 {
+		t.Errorf("Expected one warning per dropped key, got %v", warnings)
+	}
+}
+
+// Test NewAllowlistLogger to verify the dropped field warning is timestamped by Config.Clock
+func TestNewAllowlistLogger_Clock(t *testing.T) {
+	frozen := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	base, buf := newBufferedZap(t, &Config{IsJson: true, Clock: func() time.Time { return frozen }}, InfoLevel)
+	NewAllowlistLogger(base, nil).Infow("entry", "secret", "x")
+
+	entries := decodeEntries(t, buf.String())
+	if len(entries) != 2 {
+		t.Fatalf("Expected a warning and the entry, got %v", entries)
+	}
+	for _, entry := range entries {
+		if entry["timestamp"] != "2024-05-01T12:00:00.000Z" {
+			t.Errorf("Expected the frozen time, got %v", entry)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package log
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// BackpressurePolicy decides what an AsyncWriter does when its buffer is full.
+type BackpressurePolicy string
+
+const (
+	// BackpressureBlock makes writers wait until the buffer has room. It is the default.
+	BackpressureBlock BackpressurePolicy = "block"
+	// BackpressureDropOldest discards the oldest buffered line to make room for the new one.
+	BackpressureDropOldest BackpressurePolicy = "drop_oldest"
+	// BackpressureDropNewest discards the line being written.
+	BackpressureDropNewest BackpressurePolicy = "drop_newest"
+)
+
+// errAsyncWriterClosed is returned by writes to a closed AsyncWriter.
+var errAsyncWriterClosed = errors.New("async writer is closed")
+
+// AsyncWriter is a zapcore.WriteSyncer that hands lines to a background goroutine through a bounded queue.
+type AsyncWriter struct {
+	out     zapcore.WriteSyncer
+	policy  BackpressurePolicy
+	queue   chan []byte
+	flush   chan chan struct{}
+	writing sync.RWMutex  // Held for reading while a line is queued, so Close can wait for writes in flight.
+	closing chan struct{} // Closed first by Close, releasing blocked writers.
+	done    chan struct{} // Closed by Close once no more lines can be queued, stopping run.
+	stopped chan struct{} // Closed by run after the final drain.
+	closed  atomic.Bool
+	dropped atomic.Uint64
+}
+
+// NewAsyncWriter creates an AsyncWriter buffering up to size lines for out.
+// Unknown policies fall back to BackpressureBlock.
+func NewAsyncWriter(out zapcore.WriteSyncer, size int, policy BackpressurePolicy) *AsyncWriter {
+	if policy != BackpressureDropOldest && policy != BackpressureDropNewest {
+		policy = BackpressureBlock
+	}
+	w := &AsyncWriter{
+		out:     out,
+		policy:  policy,
+		queue:   make(chan []byte, size),
+		flush:   make(chan chan struct{}),
+		closing: make(chan struct{}),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// run writes queued lines to the destination until the writer is closed.
+func (w *AsyncWriter) run() {
+	defer close(w.stopped)
+	for {
+		select {
+		case p := <-w.queue:
+			_, _ = w.out.Write(p)
+		case ack := <-w.flush:
+			w.drain()
+			_ = w.out.Sync()
+			close(ack)
+		case <-w.done:
+			w.drain()
+			_ = w.out.Sync()
+			return
+		}
+	}
+}
+
+// drain writes every line currently in the queue.
+func (w *AsyncWriter) drain() {
+	for {
+		select {
+		case p := <-w.queue:
+			_, _ = w.out.Write(p)
+		default:
+			return
+		}
+	}
+}
+
+// Write queues a copy of p according to the backpressure policy.
+func (w *AsyncWriter) Write(p []byte) (int, error) {
+	w.writing.RLock()
+	defer w.writing.RUnlock()
+	if w.closed.Load() {
+		return 0, errAsyncWriterClosed
+	}
+	// zap reuses its buffers once Write returns.
+	line := append([]byte(nil), p...)
+
+	switch w.policy {
+	case BackpressureDropNewest:
+		select {
+		case w.queue <- line:
+		default:
+			w.dropped.Add(1)
+		}
+	case BackpressureDropOldest:
+		for {
+			select {
+			case w.queue <- line:
+				return len(p), nil
+			default:
+			}
+			select {
+			case <-w.queue:
+				w.dropped.Add(1)
+			default:
+			}
+		}
+	default:
+		select {
+		case w.queue <- line:
+		case <-w.closing:
+			return 0, errAsyncWriterClosed
+		}
+	}
+	return len(p), nil
+}
+
+// Sync blocks until every queued line is written and the destination is flushed.
+func (w *AsyncWriter) Sync() error {
+	ack := make(chan struct{})
+	select {
+	case w.flush <- ack:
+		<-ack
+		return nil
+	case <-w.done:
+		return errAsyncWriterClosed
+	}
+}
+
+// Close stops accepting lines, waits until the queued lines are written and the destination is
+// flushed, and stops the background goroutine. Writes after Close fail.
+func (w *AsyncWriter) Close() error {
+	if w.closed.CompareAndSwap(false, true) {
+		close(w.closing)
+		// Wait for writes that passed the closed check to finish queueing.
+		w.writing.Lock()
+		close(w.done)
+		w.writing.Unlock()
+	}
+	<-w.stopped
+	return nil
+}
+
+// Dropped returns the number of lines discarded because the buffer was full.
+func (w *AsyncWriter) Dropped() uint64 {
+	return w.dropped.Load()
+}
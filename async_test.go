@@ -0,0 +1,121 @@
+package log
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+)
+
+// gatedWriter blocks every write until its gate is opened
+type gatedWriter struct {
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	entered chan struct{}
+	gate    chan struct{}
+}
+
+func newGatedWriter() *gatedWriter {
+	return &gatedWriter{entered: make(chan struct{}, 16), gate: make(chan struct{})}
+}
+
+func (g *gatedWriter) Write(p []byte) (int, error) {
+	g.entered <- struct{}{}
+	<-g.gate
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.buf.Write(p)
+}
+
+func (g *gatedWriter) Sync() error { return nil }
+
+func (g *gatedWriter) String() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.buf.String()
+}
+
+// fillAsyncWriter writes "0" (held by the blocked consumer) and then fills a two-line buffer with "1" and "2"
+func fillAsyncWriter(t *testing.T, policy BackpressurePolicy) (*AsyncWriter, *gatedWriter) {
+	t.Helper()
+	out := newGatedWriter()
+	w := NewAsyncWriter(out, 2, policy)
+	w.Write([]byte("0"))
+	<-out.entered
+	w.Write([]byte("1"))
+	w.Write([]byte("2"))
+	return w, out
+}
+
+// Test the drop_newest policy to verify the incoming line is discarded when full
+func TestAsyncWriter_DropNewest(t *testing.T) {
+	w, out := fillAsyncWriter(t, BackpressureDropNewest)
+	w.Write([]byte("3"))
+	close(out.gate)
+	w.Sync()
+
+	if out.String() != "012" || w.Dropped() != 1 {
+		t.Errorf("Expected output %q with 1 drop, got %q with %d", "012", out.String(), w.Dropped())
+	}
+}
+
+// Test the drop_oldest policy to verify the oldest buffered line is evicted when full
+func TestAsyncWriter_DropOldest(t *testing.T) {
+	w, out := fillAsyncWriter(t, BackpressureDropOldest)
+	w.Write([]byte("3"))
+	close(out.gate)
+	w.Sync()
+
+	if out.String() != "023" || w.Dropped() != 1 {
+		t.Errorf("Expected output %q with 1 drop, got %q with %d", "023", out.String(), w.Dropped())
+	}
+}
+
+// Test the block policy to verify writers wait for room and nothing is dropped
+func TestAsyncWriter_Block(t *testing.T) {
+	w, out := fillAsyncWriter(t, BackpressureBlock)
+
+	wrote := make(chan struct{})
+	go func() {
+		w.Write([]byte("3"))
+		close(wrote)
+	}()
+	select {
+	case <-wrote:
+		t.Fatal("Expected write to block while the buffer is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(out.gate)
+	<-wrote
+	w.Sync()
+
+	if out.String() != "0123" || w.Dropped() != 0 {
+		t.Errorf("Expected output %q with no drops, got %q with %d", "0123", out.String(), w.Dropped())
+	}
+}
+
+// Test Close to verify it returns only after the queued lines are written and later writes fail
+func TestAsyncWriter_Close(t *testing.T) {
+	w, out := fillAsyncWriter(t, BackpressureBlock)
+
+	closed := make(chan struct{})
+	go func() {
+		w.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+		t.Fatal("Expected Close to wait for the queued lines")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(out.gate)
+	<-closed
+	if out.String() != "012" {
+		t.Errorf("Expected all queued lines written by Close, got %q", out.String())
+	}
+	if _, err := w.Write([]byte("3")); err == nil {
+		t.Error("Expected a write after Close to fail")
+	}
+}
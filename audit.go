@@ -0,0 +1,132 @@
+package log
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// auditHashKey is the key of the hash member inserted into sealed lines.
+const auditHashKey = "hash"
+
+// sealPool provides the buffers sealed lines are rebuilt in.
+var sealPool = buffer.NewPool()
+
+// auditChain is the state shared by every core of an audit logger.
+type auditChain struct {
+	mu   sync.Mutex
+	seq  uint64
+	prev string
+}
+
+// auditSeal travels with an entry as a skipped field and receives the hash of the encoded line.
+type auditSeal struct {
+	hash string
+}
+
+// auditCore wraps a zapcore.Core and adds tamper-evident sequence and hash chain fields.
+type auditCore struct {
+	zapcore.Core
+	chain *auditChain
+}
+
+// NewAuditLogger returns a logger that adds "seq", "prev_hash" and "hash" fields to every entry.
+// seq increases by one per entry, prev_hash repeats the hash of the previous entry and hash is the
+// hex SHA-256 of the line exactly as written, without the `,"hash":"<hex>"` member that is inserted
+// as the last member of its JSON object. To verify a line, remove that member and hash the remaining
+// bytes including the line ending. Missing or reordered lines therefore break the chain. With
+// Config.Sinks the chain follows the lines of the first sink. Loggers not built by this package
+// are returned unchanged.
+func NewAuditLogger(base Logger) Logger {
+	l, ok := base.(*zapLogger)
+	if !ok {
+		return base
+	}
+
+	chain := &auditChain{}
+	return l.wrapCore(func(core zapcore.Core) zapcore.Core {
+		return &auditCore{Core: core, chain: chain}
+	})
+}
+
+// With preserves the audit chain on cores derived with additional fields.
+func (c *auditCore) With(fields []zapcore.Field) zapcore.Core {
+	return &auditCore{Core: c.Core.With(fields), chain: c.chain}
+}
+
+// Check registers the audit core for entries the wrapped core would accept.
+func (c *auditCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return checkWrapped(c.Core, c, ent, ce)
+}
+
+// Write extends the chain and writes the entry while holding the chain lock, so output order matches seq.
+// The hash is filled in by the sealingEncoder of the wrapped core; entries that were not encoded do not
+// advance the chain.
+func (c *auditCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	c.chain.mu.Lock()
+	defer c.chain.mu.Unlock()
+
+	seal := &auditSeal{}
+	fields = appendFields(fields,
+		zap.Uint64("seq", c.chain.seq+1),
+		zap.String("prev_hash", c.chain.prev),
+		zapcore.Field{Type: zapcore.SkipType, Interface: seal},
+	)
+	err := c.Core.Write(ent, fields)
+	if seal.hash != "" {
+		c.chain.seq++
+		c.chain.prev = seal.hash
+	}
+	return err
+}
+
+// sealingEncoder wraps the encoder of a zapLogger and inserts the hash of the encoded line into entries
+// written by an audit logger.
+type sealingEncoder struct {
+	zapcore.Encoder
+}
+
+// Clone keeps sealing on encoders cloned for derived loggers.
+func (e sealingEncoder) Clone() zapcore.Encoder {
+	return sealingEncoder{e.Encoder.Clone()}
+}
+
+// EncodeEntry encodes the entry and, if it carries an auditSeal, hashes the line and inserts the hash
+// before the closing brace of its last JSON object.
+func (e sealingEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	var seal *auditSeal
+	for i := len(fields) - 1; i >= 0; i-- {
+		if s, ok := fields[i].Interface.(*auditSeal); ok && fields[i].Type == zapcore.SkipType {
+			seal = s
+			fields = append(fields[:i:i], fields[i+1:]...)
+			break
+		}
+	}
+	buf, err := e.Encoder.EncodeEntry(ent, fields)
+	if err != nil || seal == nil {
+		return buf, err
+	}
+
+	line := buf.Bytes()
+	end := bytes.LastIndexByte(line, '}')
+	if end < 0 {
+		return buf, nil
+	}
+	sum := sha256.Sum256(line)
+	hash := hex.EncodeToString(sum[:])
+	if seal.hash == "" {
+		seal.hash = hash
+	}
+
+	out := sealPool.Get()
+	out.Write(line[:end])
+	out.AppendString(`,"` + auditHashKey + `":"` + hash + `"`)
+	out.Write(line[end:])
+	buf.Free()
+	return out, nil
+}
@@ -0,0 +1,25 @@
+package log
+
+import (
+	"context"
+	"sort"
+
+	"go.opentelemetry.io/otel/baggage"
+)
+
+// baggagePrefix is prepended to the keys of baggage members attached as fields.
+const baggagePrefix = "baggage."
+
+// appendBaggage appends the OpenTelemetry baggage members of ctx to kvs as "baggage.<key>" pairs.
+// Members are taken in key order so that the same ones are kept when there are more than max.
+func appendBaggage(kvs []interface{}, ctx context.Context, max int) []interface{} {
+	members := baggage.FromContext(ctx).Members()
+	sort.Slice(members, func(i, j int) bool { return members[i].Key() < members[j].Key() })
+	if len(members) > max {
+		members = members[:max]
+	}
+	for _, m := range members {
+		kvs = append(kvs, baggagePrefix+m.Key(), m.Value())
+	}
+	return kvs
+}
@@ -0,0 +1,61 @@
+package log
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/baggage"
+)
+
+// contextWithBaggage returns a context carrying baggage with the given key-value pairs.
+func contextWithBaggage(t *testing.T, kvs ...string) context.Context {
+	t.Helper()
+	var members []baggage.Member
+	for i := 0; i+1 < len(kvs); i += 2 {
+		m, err := baggage.NewMember(kvs[i], kvs[i+1])
+		if err != nil {
+			t.Fatalf("Unexpected error creating member: %v", err)
+		}
+		members = append(members, m)
+	}
+	b, err := baggage.New(members...)
+	if err != nil {
+		t.Fatalf("Unexpected error creating baggage: %v", err)
+	}
+	return baggage.ContextWithBaggage(context.Background(), b)
+}
+
+// Test FromContext to verify baggage members become "baggage.<key>" fields when enabled
+func TestFromContext_Baggage(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true, BaggageMembers: 5}, InfoLevel)
+	ctx := contextWithBaggage(t, "tenant", "acme", "plan", "gold")
+
+	FromContext(ToContext(ctx, logger)).Info("scoped")
+
+	entry := decodeEntry(t, buf)
+	if entry["baggage.tenant"] != "acme" || entry["baggage.plan"] != "gold" {
+		t.Errorf("Expected baggage fields, got %v", entry)
+	}
+}
+
+// Test WithContext to verify baggage is bounded by BaggageMembers and ignored when unset
+func TestWithContext_BaggageLimit(t *testing.T) {
+	ctx := contextWithBaggage(t, "tenant", "acme", "plan", "gold")
+
+	logger, buf := newBufferedZap(t, &Config{IsJson: true, BaggageMembers: 1}, InfoLevel)
+	logger.WithContext(ctx).Info("bounded")
+	entry := decodeEntry(t, buf)
+	if entry["baggage.plan"] != "gold" {
+		t.Errorf("Expected the first member by key, got %v", entry)
+	}
+	if _, ok := entry["baggage.tenant"]; ok {
+		t.Errorf("Expected members beyond the limit to be dropped, got %v", entry)
+	}
+
+	logger, buf = newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	logger.WithContext(ctx).Info("disabled")
+	entry = decodeEntry(t, buf)
+	if _, ok := entry["baggage.plan"]; ok {
+		t.Errorf("Expected no baggage fields by default, got %v", entry)
+	}
+}
@@ -0,0 +1,62 @@
+package log
+
+import (
+	"go.uber.org/zap/zapcore"
+)
+
+// BufferHandle flushes and stops the output buffers of a logger created with Config.FlushIntervalMs
+// or Config.AsyncBufferSize. For loggers without a buffer its methods do nothing.
+type BufferHandle struct {
+	buffered *zapcore.BufferedWriteSyncer
+	async    *AsyncWriter
+}
+
+// NewBufferedLogger creates a Logger like NewLogger and returns a handle to its output buffers.
+// With a positive FlushIntervalMs, output is buffered and written every interval, when the buffer
+// fills up, or on Flush. With a positive AsyncBufferSize, output goes through an AsyncWriter.
+// Call Stop before the process exits so buffered lines are not lost.
+func NewBufferedLogger(conf *Config) (Logger, *BufferHandle, error) {
+	level, err := configLevel(conf)
+	if err != nil {
+		return nil, nil, err
+	}
+	l, err := newZapFromConfig(conf, level)
+	if err != nil {
+		return nil, nil, err
+	}
+	handle := &BufferHandle{}
+	if zl, ok := l.(*zapLogger); ok {
+		handle.buffered, handle.async = zl.buffered, zl.async
+	}
+	return l, handle, nil
+}
+
+// Flush writes the buffered lines to the output.
+func (h *BufferHandle) Flush() error {
+	if h.async != nil {
+		if err := h.async.Sync(); err != nil {
+			return err
+		}
+	}
+	if h.buffered == nil {
+		return nil
+	}
+	return h.buffered.Sync()
+}
+
+// Stop flushes the buffered lines, closes the AsyncWriter and stops the periodic flush.
+func (h *BufferHandle) Stop() error {
+	if h.async != nil {
+		_ = h.async.Close()
+	}
+	if h.buffered == nil {
+		return nil
+	}
+	return h.buffered.Stop()
+}
+
+// AsyncWriter returns the AsyncWriter of a logger created with Config.AsyncBufferSize, for example
+// to read Dropped, or nil.
+func (h *BufferHandle) AsyncWriter() *AsyncWriter {
+	return h.async
+}
@@ -0,0 +1,92 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readLog returns the content of the log file at path
+func readLog(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// Test NewBufferedLogger to verify lines are held until an explicit Flush
+func TestNewBufferedLogger_Flush(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger, handle, err := NewBufferedLogger(&Config{Level: "INFO", OutputPaths: []string{path}, FlushIntervalMs: 3600000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer handle.Stop()
+
+	logger.Info("buffered")
+	if out := readLog(t, path); out != "" {
+		t.Errorf("Expected nothing written before the flush, got %q", out)
+	}
+	if err := handle.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if out := readLog(t, path); !strings.Contains(out, "buffered") {
+		t.Errorf("Expected the line after Flush, got %q", out)
+	}
+}
+
+// Test FlushIntervalMs to verify lines appear once the interval elapses
+func TestNewBufferedLogger_Interval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger, handle, err := NewBufferedLogger(&Config{Level: "INFO", OutputPaths: []string{path}, FlushIntervalMs: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer handle.Stop()
+
+	logger.Info("periodic")
+	deadline := time.Now().Add(2 * time.Second)
+	for !strings.Contains(readLog(t, path), "periodic") {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the line to be flushed by the interval")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// Test NewBufferedLogger to verify the handle is a no-op without buffering
+func TestNewBufferedLogger_Unbuffered(t *testing.T) {
+	_, handle, err := NewBufferedLogger(&Config{Level: "INFO", OutputPaths: []string{filepath.Join(t.TempDir(), "app.log")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if handle.Flush() != nil || handle.Stop() != nil {
+		t.Error("Expected no-op handle methods without FlushIntervalMs")
+	}
+}
+
+// Test NewBufferedLogger to verify the AsyncWriter of Config.AsyncBufferSize is reachable and drained by Stop
+func TestNewBufferedLogger_Async(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger, handle, err := NewBufferedLogger(&Config{Level: "INFO", OutputPaths: []string{path}, AsyncBufferSize: 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if handle.AsyncWriter() == nil {
+		t.Fatal("Expected the handle to expose the AsyncWriter")
+	}
+
+	for i := 0; i < 10; i++ {
+		logger.Info("queued")
+	}
+	if err := handle.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(readLog(t, path), "queued"); n != 10 {
+		t.Errorf("Expected 10 lines after Stop, got %d", n)
+	}
+}
@@ -0,0 +1,32 @@
+package log
+
+import (
+	"runtime/debug"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// buildInfoOnce guards the one-time build information lookup used by Config.IncludeBuildInfo.
+var (
+	buildInfoOnce   sync.Once
+	buildInfoValues []zap.Field
+)
+
+// buildInfoFields returns the Go version and, when the binary was built with VCS stamping,
+// the "vcs.revision" and "vcs.time" settings as fields. They are read once per process.
+func buildInfoFields() []zap.Field {
+	buildInfoOnce.Do(func() {
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+		buildInfoValues = append(buildInfoValues, zap.String("go_version", info.GoVersion))
+		for _, s := range info.Settings {
+			if s.Key == "vcs.revision" || s.Key == "vcs.time" {
+				buildInfoValues = append(buildInfoValues, zap.String(s.Key, s.Value))
+			}
+		}
+	})
+	return buildInfoValues
+}
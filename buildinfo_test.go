@@ -0,0 +1,21 @@
+package log
+
+import (
+	"runtime"
+	"testing"
+)
+
+// Test that build information fields are attached when enabled in Config
+func TestZapLogger_IncludeBuildInfo(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true, IncludeBuildInfo: true}, InfoLevel)
+	logger.Info("hello")
+
+	entry := decodeEntry(t, buf)
+	if entry["go_version"] != runtime.Version() {
+		t.Errorf("Expected go_version %q, got %v", runtime.Version(), entry["go_version"])
+	}
+	// Test binaries are not VCS-stamped, so the revision may be absent but must not be empty.
+	if rev, ok := entry["vcs.revision"]; ok && rev == "" {
+		t.Errorf("Expected a populated or absent vcs.revision, got %q", rev)
+	}
+}
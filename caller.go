@@ -0,0 +1,207 @@
+package log
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// CallerMode selects how the caller of an entry is rendered.
+type CallerMode string
+
+const (
+	// CallerFile renders the caller as "path/file.go:line". It is the default.
+	CallerFile CallerMode = "file"
+	// CallerFunction renders the caller as a package-qualified function name, stable across edits.
+	CallerFunction CallerMode = "function"
+	// CallerBoth renders the function name followed by the file location.
+	CallerBoth CallerMode = "both"
+)
+
+// callerEncoder renders callers according to mode. File paths under prefix are shown relative to it,
+// so files under a module root appear as "internal/foo/bar.go:12"; other paths keep the default
+// two-segment trim. The brackets flag selects the console "[caller]:" format over the plain JSON one.
+func callerEncoder(prefix string, mode CallerMode, brackets bool) zapcore.CallerEncoder {
+	return func(caller zapcore.EntryCaller, enc zapcore.PrimitiveArrayEncoder) {
+		var rendered string
+		switch mode {
+		case CallerFunction:
+			rendered = callerFunction(caller)
+		case CallerBoth:
+			rendered = callerFunction(caller) + " " + callerFile(caller, prefix)
+		default:
+			rendered = callerFile(caller, prefix)
+		}
+		if brackets {
+			rendered = "[" + rendered + "]:"
+		}
+		enc.AppendString(rendered)
+	}
+}
+
+// callerFile returns the caller location as "path:line", relative to prefix when the file lies under it.
+// The prefix must end at a path separator of the file, so "/src/mod" does not match "/src/module".
+func callerFile(caller zapcore.EntryCaller, prefix string) string {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if rel, ok := strings.CutPrefix(caller.File, prefix+"/"); ok && prefix != "" && caller.Defined {
+		return rel + ":" + strconv.Itoa(caller.Line)
+	}
+	return caller.TrimmedPath()
+}
+
+// callerFunction returns the caller function qualified by the last element of its package path,
+// e.g. "server.(*Handler).ServeHTTP".
+func callerFunction(caller zapcore.EntryCaller) string {
+	name := caller.Function
+	if name == "" {
+		fn := runtime.FuncForPC(caller.PC)
+		if fn == nil {
+			return "undefined"
+		}
+		name = fn.Name()
+	}
+	if i := strings.LastIndexByte(name, '/'); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// callerPackage returns the import path of the package containing the caller function,
+// e.g. "github.com/org/app/internal/server" for "github.com/org/app/internal/server.(*Handler).ServeHTTP".
+// Dots in the last path element, escaped as "%2e" in function names, are restored.
+func callerPackage(caller zapcore.EntryCaller) string {
+	name := caller.Function
+	if name == "" {
+		fn := runtime.FuncForPC(caller.PC)
+		if fn == nil {
+			return ""
+		}
+		name = fn.Name()
+	}
+	slash := strings.LastIndexByte(name, '/') + 1
+	if dot := strings.IndexByte(name[slash:], '.'); dot >= 0 {
+		name = name[:slash+dot]
+	}
+	return strings.ReplaceAll(name, "%2e", ".")
+}
+
+// addCallerPackage attaches the import path of the caller's package as a "package" field.
+func addCallerPackage(ent zapcore.Entry, fields []zapcore.Field) (zapcore.Entry, []zapcore.Field) {
+	if !ent.Caller.Defined {
+		return ent, fields
+	}
+	if pkg := callerPackage(ent.Caller); pkg != "" {
+		fields = appendFields(fields, zap.String("package", pkg))
+	}
+	return ent, fields
+}
+
+// callerAbove returns the frame skip levels above caller on the current stack.
+// The caller is returned unchanged when it cannot be located or the stack is too shallow.
+func callerAbove(caller zapcore.EntryCaller, skip int) zapcore.EntryCaller {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+
+	remaining := -1
+	for {
+		frame, more := frames.Next()
+		switch {
+		case remaining < 0 && frame.PC == caller.PC:
+			remaining = skip
+		case remaining > 0:
+			remaining--
+		}
+		if remaining == 0 {
+			return zapcore.EntryCaller{
+				Defined:  true,
+				PC:       frame.PC,
+				File:     frame.File,
+				Line:     frame.Line,
+				Function: frame.Function,
+			}
+		}
+		if !more {
+			return caller
+		}
+	}
+}
+
+// maxCallerDepth bounds the stack frames inspected by clampCaller.
+const maxCallerDepth = 64
+
+// callerClamp is carried by a context field of kind zapcore.SkipType to arm the clampCallerCore
+// of a logger (true, set by SkipCallers) or disarm it (false, set where callers are disabled).
+type callerClamp bool
+
+// callerClampField returns the context field arming or disarming caller clamping.
+func callerClampField(armed bool) zapcore.Field {
+	return zapcore.Field{Type: zapcore.SkipType, Interface: callerClamp(armed)}
+}
+
+// clampCallerCore wraps a zapcore.Core and applies clampCaller to the entries of loggers that
+// requested a caller skip. Entries of loggers with callers disabled are left alone.
+type clampCallerCore struct {
+	zapcore.Core
+	armed bool
+}
+
+// With arms or disarms clamping according to a callerClamp field among fields.
+func (c *clampCallerCore) With(fields []zapcore.Field) zapcore.Core {
+	armed := c.armed
+	for _, f := range fields {
+		if clamp, ok := f.Interface.(callerClamp); ok && f.Type == zapcore.SkipType {
+			armed = bool(clamp)
+		}
+	}
+	return &clampCallerCore{Core: c.Core.With(fields), armed: armed}
+}
+
+// Check registers the clamping core for entries the wrapped core would accept.
+func (c *clampCallerCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return checkWrapped(c.Core, c, ent, ce)
+}
+
+// Write clamps the caller when armed and passes the entry to the wrapped core.
+func (c *clampCallerCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if c.armed {
+		ent, fields = clampCaller(ent, fields)
+	}
+	return c.Core.Write(ent, fields)
+}
+
+// clampCaller replaces the caller zap reports when a caller skip exceeds the stack at the time of
+// logging, an undefined caller or one in the runtime package, with the deepest frame outside the
+// runtime package, and reports the replacement as an internal error.
+func clampCaller(ent zapcore.Entry, fields []zapcore.Field) (zapcore.Entry, []zapcore.Field) {
+	if ent.Caller.Defined && !inRuntime(ent.Caller.PC) {
+		return ent, fields
+	}
+	pcs := make([]uintptr, maxCallerDepth)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(1, pcs)])
+	var deepest runtime.Frame
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "runtime.") {
+			deepest = frame
+		}
+		if !more {
+			break
+		}
+	}
+	if deepest.PC == 0 {
+		return ent, fields
+	}
+	ent.Caller = zapcore.EntryCaller{Defined: true, PC: deepest.PC, File: deepest.File, Line: deepest.Line, Function: deepest.Function}
+	reportInternalError(fmt.Errorf("caller skip exceeds the stack depth, using %s", deepest.Function))
+	return ent, fields
+}
+
+// inRuntime reports whether pc belongs to a function of the runtime package.
+func inRuntime(pc uintptr) bool {
+	fn := runtime.FuncForPC(pc)
+	return fn != nil && strings.HasPrefix(fn.Name(), "runtime.")
+}
@@ -0,0 +1,121 @@
+package log
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Test callerEncoder to verify paths under the prefix are rendered relative to it
+func TestCallerEncoder_TrimPrefix(t *testing.T) {
+	tests := []struct {
+		file     string
+		brackets bool
+		want     string
+	}{
+		{"/src/mod/internal/foo/bar.go", false, "internal/foo/bar.go:12"},
+		{"/src/mod/internal/foo/bar.go", true, "[internal/foo/bar.go:12]:"},
+		{"/other/pkg/baz.go", false, "pkg/baz.go:12"},
+		{"/src/module/qux.go", false, "module/qux.go:12"},
+	}
+
+	for _, tt := range tests {
+		encoder := zapcore.NewConsoleEncoder(zapcore.EncoderConfig{
+			CallerKey:    "caller",
+			EncodeCaller: callerEncoder("/src/mod", CallerFile, tt.brackets),
+		})
+		entry := zapcore.Entry{Caller: zapcore.NewEntryCaller(0, tt.file, 12, true)}
+
+		buf, err := encoder.EncodeEntry(entry, nil)
+		if err != nil {
+			t.Fatalf("Encoding caller entry failed: %v", err)
+		}
+		if !contains(buf.String(), tt.want) {
+			t.Errorf("Caller for %s = %q; want %q", tt.file, buf.String(), tt.want)
+		}
+	}
+}
+
+// Test CallerMode to verify the shape of the caller for each mode from a known call site
+func TestCallerMode(t *testing.T) {
+	tests := []struct {
+		mode CallerMode
+		want []string
+	}{
+		{CallerFile, []string{"caller_test.go:"}},
+		{CallerFunction, []string{"go-logger.TestCallerMode"}},
+		{CallerBoth, []string{"go-logger.TestCallerMode", "caller_test.go:"}},
+	}
+
+	for _, tt := range tests {
+		logger, buf := newBufferedZap(t, &Config{IsJson: true, CallerMode: tt.mode}, InfoLevel)
+		logger.Info("caller mode")
+
+		caller, _ := decodeEntry(t, buf)["module"].(string)
+		for _, want := range tt.want {
+			if !strings.Contains(caller, want) {
+				t.Errorf("CallerMode %q caller = %q; want it to contain %q", tt.mode, caller, want)
+			}
+		}
+		if tt.mode == CallerFunction && strings.Contains(caller, ".go:") {
+			t.Errorf("CallerMode %q caller = %q; want no file location", tt.mode, caller)
+		}
+	}
+}
+
+// callerAtHelper logs through l and returns the line of its log call
+func callerAtHelper(l Logger, msg string) int {
+	_, _, line, _ := runtime.Caller(0)
+	l.Info(msg)
+	return line + 1
+}
+
+// Test CallerAt to verify only the next entry reports the extra frame
+func TestZapLogger_CallerAt(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	oneShot := logger.CallerAt(1)
+
+	_, _, line, _ := runtime.Caller(0)
+	callerAtHelper(oneShot, "first")
+	helperLine := callerAtHelper(oneShot, "second")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected two entries, got %q", buf.String())
+	}
+	if want := "caller_test.go:" + strconv.Itoa(line+1); !strings.Contains(lines[0], want) {
+		t.Errorf("Expected first entry caller %s, got %s", want, lines[0])
+	}
+	if want := "caller_test.go:" + strconv.Itoa(helperLine); !strings.Contains(lines[1], want) {
+		t.Errorf("Expected second entry caller %s, got %s", want, lines[1])
+	}
+}
+
+// Test IncludePackage to verify entries carry the import path of the calling package
+func TestIncludePackage(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true, IncludePackage: true}, InfoLevel)
+	logger.Info("attributed")
+
+	entry := decodeEntry(t, buf)
+	if entry["package"] != "github.com/vadymlab/go-logger" {
+		t.Errorf("Expected package %q, got %v", "github.com/vadymlab/go-logger", entry["package"])
+	}
+}
+
+// Test callerPackage to verify methods, closures and escaped dots resolve to their package path
+func TestCallerPackage(t *testing.T) {
+	tests := map[string]string{
+		"github.com/org/app/internal/server.(*Handler).ServeHTTP": "github.com/org/app/internal/server",
+		"github.com/org/app.main.func1":                           "github.com/org/app",
+		"main.main":                                               "main",
+		"gopkg.in/yaml%2ev3.Marshal":                              "gopkg.in/yaml.v3",
+	}
+	for function, want := range tests {
+		if got := callerPackage(zapcore.EntryCaller{Defined: true, Function: function}); got != want {
+			t.Errorf("callerPackage(%q) = %q; want %q", function, got, want)
+		}
+	}
+}
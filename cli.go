@@ -0,0 +1,58 @@
+package log
+
+import (
+	"io"
+	"os"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewCLILogger returns a logger for command-line tools: colored console output on stderr showing
+// Info and above, or Debug and above when verbose is set, plus every entry down to Trace written
+// as JSON to jsonFile. SetLevel and Check apply to the file output.
+func NewCLILogger(verbose bool, jsonFile string) (Logger, error) {
+	return newCLILogger(verbose, jsonFile, zapcore.Lock(os.Stderr))
+}
+
+// newCLILogger builds a CLI logger writing its console output to console.
+func newCLILogger(verbose bool, jsonFile string, console zapcore.WriteSyncer) (Logger, error) {
+	consoleLevel := InfoLevel
+	if verbose {
+		consoleLevel = DebugLevel
+	}
+	term, err := buildZap(&Config{}, consoleLevel, console)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := openFileSink(jsonFile)
+	if err != nil {
+		return nil, err
+	}
+	full, err := buildZap(&Config{IsJson: true}, TraceLevel, file)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	core := zapcore.NewTee(
+		&levelGateCore{Core: term.log.Desugar().Core(), min: *convLevel(consoleLevel)},
+		full.log.Desugar().Core(),
+	)
+	logger := zap.New(core,
+		zap.ErrorOutput(zapcore.AddSync(io.Discard)),
+		zap.AddCaller(),
+		zap.AddStacktrace(zap.WarnLevel),
+		zap.WithFatalHook(exitHook{defaultExitCode}),
+	).Sugar()
+	return &zapLogger{
+		log:        *logger,
+		plain:      &desugaredCache{},
+		traceLevel: true,
+		configured: true,
+		levels:     full.levels,
+		root:       logger,
+		files:      []*fileSink{file},
+	}, nil
+}
@@ -0,0 +1,49 @@
+package log
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Test NewCLILogger to verify Debug reaches only the file unless verbose is set
+func TestNewCLILogger(t *testing.T) {
+	for _, verbose := range []bool{false, true} {
+		path := filepath.Join(t.TempDir(), "cli.json")
+		console := &bytes.Buffer{}
+		logger, err := newCLILogger(verbose, path, zapcore.AddSync(console))
+		if err != nil {
+			t.Fatal(err)
+		}
+		logger.Debug("details")
+		logger.Info("done")
+		logger.(*zapLogger).Trace("internals")
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries := decodeEntries(t, string(data))
+		if len(entries) != 3 || entries[0]["severity"] != "debug" || entries[2]["severity"] != "trace" {
+			t.Errorf("verbose=%v: expected 3 plain JSON file entries, got %q", verbose, data)
+		}
+		out := console.String()
+		if !strings.Contains(out, "done") || strings.Contains(out, "internals") {
+			t.Errorf("verbose=%v: unexpected console output %q", verbose, out)
+		}
+		if strings.Contains(out, "details") != verbose {
+			t.Errorf("verbose=%v: debug on console = %v, got %q", verbose, !verbose, out)
+		}
+	}
+}
+
+// Test NewCLILogger to verify a JSON file in a missing directory is rejected
+func TestNewCLILogger_BadPath(t *testing.T) {
+	if _, err := NewCLILogger(false, filepath.Join(t.TempDir(), "missing", "cli.json")); err == nil {
+		t.Error("Expected an error for an unwritable JSON file")
+	}
+}
@@ -1,11 +1,55 @@
 package log
 
+import "time"
+
 // Config defines the logging configuration structure.
 // Level sets the logging level (e.g., "info", "debug", "error").
 // IsJson toggles between JSON format (true) or plain text format (false) for log output.
+// IncludeHost and IncludePID attach the machine hostname and process ID to every entry.
+// EventLogSource redirects output to the Windows Event Log and is rejected on other platforms.
+// Development enables developer-oriented behavior such as SourceSnippet and must stay off in production.
+// PanicStacktrace controls how much context Panic methods record before panicking.
 type Config struct {
-	Level  string // Level defines the logging severity (e.g., "info", "debug").
-	IsJson bool   // IsJson determines if the log output should be in JSON format.
+	Level               string              // Level defines the logging severity (e.g., "info", "debug").
+	IsJson              bool                // IsJson determines if the log output should be in JSON format.
+	IncludeHost         bool                // IncludeHost adds a "host" field with the hostname resolved once at startup.
+	IncludePID          bool                // IncludePID adds a "pid" field with the current process ID.
+	EventLogSource      string              // EventLogSource, when set, writes entries to the Windows Event Log under this source.
+	Development         bool                // Development enables developer-oriented diagnostics and makes DPanic panic.
+	SourceSnippet       bool                // SourceSnippet attaches the code around the caller to Error entries (Development only).
+	PanicSync           bool                // Deprecated: Panic methods always flush the output before unwinding the stack.
+	PanicStacktrace     bool                // PanicStacktrace attaches a "stacktrace" field to entries logged by Panic methods.
+	CallerTrimPrefix    string              // CallerTrimPrefix renders caller paths relative to this root (e.g. the module directory).
+	LevelNumber         bool                // LevelNumber adds a numeric "severityNumber" next to the textual severity.
+	DisableColor        bool                // DisableColor removes ANSI colors from console output, even on a terminal.
+	AsyncBufferSize     int                 // AsyncBufferSize, when positive, writes through an AsyncWriter buffering this many lines (see NewBufferedLogger).
+	Backpressure        BackpressurePolicy  // Backpressure selects what happens when the async buffer is full (default "block").
+	FatalExitCode       int                 // FatalExitCode is the process exit code used by Fatal (default 1).
+	FatalAction         FatalAction         // FatalAction is "exit" (default) or "panic" after fatal entries.
+	ExitOnLevel         LogLevel            // ExitOnLevel makes entries at or above this level fatal, e.g. ErrorLevel (default FatalLevel).
+	NameSeparator       string              // NameSeparator joins nested logger names, e.g. "/" for "a/b/c" (default ".").
+	CallerMode          CallerMode          // CallerMode renders the caller as "file", "function" or "both" (default "file").
+	MaxFieldBytes       int                 // MaxFieldBytes, when positive, truncates longer string and byte field values.
+	Encoding            Encoding            // Encoding selects a preset layout: "ecs", "emf" or "gcp" (JSON) or "plain" (console without color).
+	Clock               func() time.Time    // Clock supplies entry timestamps (default time.Now), e.g. a frozen clock in tests.
+	SampleRules         []SampleRule        // SampleRules thin out matching entries below Error; the first matching rule applies.
+	OutputPaths         []string            // OutputPaths lists "stdout", "stderr" or file paths to write to (default "stdout").
+	LevelColors         map[LogLevel]string // LevelColors overrides console level colors with ANSI codes, e.g. "1;31"; "" disables.
+	ContextKeys         []string            // ContextKeys lists string context keys FromContext copies into fields of the same name.
+	IncludePackage      bool                // IncludePackage adds a "package" field with the import path of the caller's package.
+	DurationEncoder     DurationFormat      // DurationEncoder renders durations as "string" (default), "seconds", "millis" or "nanos".
+	GlobalSequence      bool                // GlobalSequence adds a process-wide "global_seq" field ordering entries across loggers and goroutines.
+	FlushIntervalMs     int                 // FlushIntervalMs, when positive, buffers output and flushes it at this interval (see NewBufferedLogger).
+	BaggageMembers      int                 // BaggageMembers, when positive, attaches up to this many OpenTelemetry baggage members as "baggage.<key>".
+	IncludeBuildInfo    bool                // IncludeBuildInfo adds "go_version", "vcs.revision" and "vcs.time" from the binary build information.
+	ConsoleSlices       SliceFormat         // ConsoleSlices renders slice fields in console output as "array" (default) or "joined" with commas.
+	MetricNamespace     string              // MetricNamespace is the CloudWatch namespace of metrics with the "emf" encoding (default "aws-embedded-metrics").
+	LevelToken          bool                // LevelToken adds a "level=<name>" token after the level label in console output.
+	GCPProject          string              // GCPProject is the Google Cloud project id used to qualify trace ids with the "gcp" encoding.
+	Sinks               []Sink              // Sinks, when set, writes every entry to each sink with its own encoding, level and outputs.
+	StrictFields        bool                // StrictFields reports odd key-value counts and non-string keys in a Warn entry with a "_logger_error" field.
+	RequiredContextKeys []string            // RequiredContextKeys are attached like ContextKeys, with a Warn entry when ctx lacks one, e.g. "tenant".
+	StacktraceMaxFrames int                 // StacktraceMaxFrames, when positive, cuts stack traces after this many frames with a "…(truncated)" marker.
 }
 
 // LoggerConfig holds the global logging configuration instance.
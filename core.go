@@ -0,0 +1,83 @@
+package log
+
+import (
+	"go.uber.org/zap/zapcore"
+)
+
+// entryTransform rewrites an entry and its fields right before they are written.
+type entryTransform func(ent zapcore.Entry, fields []zapcore.Field) (zapcore.Entry, []zapcore.Field)
+
+// transformCore wraps a zapcore.Core and applies a transform to every entry it writes.
+type transformCore struct {
+	zapcore.Core
+	transform entryTransform
+}
+
+// newTransformCore wraps core so that transform is applied to each written entry.
+func newTransformCore(core zapcore.Core, transform entryTransform) zapcore.Core {
+	return &transformCore{Core: core, transform: transform}
+}
+
+// With preserves the transform on cores derived with additional fields.
+func (c *transformCore) With(fields []zapcore.Field) zapcore.Core {
+	return &transformCore{Core: c.Core.With(fields), transform: c.transform}
+}
+
+// Check registers the transforming core for entries the wrapped core would accept.
+func (c *transformCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return checkWrapped(c.Core, c, ent, ce)
+}
+
+// Write applies the transform and passes the result to the wrapped core.
+func (c *transformCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ent, fields = c.transform(ent, fields)
+	return c.Core.Write(ent, fields)
+}
+
+// checkWrapped adds wrapper to ce when the inner core would accept the entry,
+// so the wrapper receives the Write call and forwards it to inner itself.
+func checkWrapped(inner, wrapper zapcore.Core, ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if inner.Check(ent, nil) != nil {
+		return ce.AddCore(ent, wrapper)
+	}
+	return ce
+}
+
+// appendFields appends extra to fields without modifying the caller's backing array.
+func appendFields(fields []zapcore.Field, extra ...zapcore.Field) []zapcore.Field {
+	return append(fields[:len(fields):len(fields)], extra...)
+}
+
+// levelGateCore wraps a zapcore.Core and ignores entries below min, including entries written
+// directly without a Check, as tee cores and trace entries do. min is a fixed zapcore.Level or
+// an atomic level that can change after construction.
+type levelGateCore struct {
+	zapcore.Core
+	min zapcore.LevelEnabler
+}
+
+// Enabled reports whether lvl is at least min and enabled on the wrapped core.
+func (c *levelGateCore) Enabled(lvl zapcore.Level) bool {
+	return c.min.Enabled(lvl) && c.Core.Enabled(lvl)
+}
+
+// With preserves the gate on cores derived with additional fields.
+func (c *levelGateCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelGateCore{Core: c.Core.With(fields), min: c.min}
+}
+
+// Check skips the wrapped core for entries below min.
+func (c *levelGateCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.min.Enabled(ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
+
+// Write drops entries below min and passes the others to the wrapped core.
+func (c *levelGateCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if !c.min.Enabled(ent.Level) {
+		return nil
+	}
+	return c.Core.Write(ent, fields)
+}
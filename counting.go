@@ -0,0 +1,126 @@
+package log
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// DropCountingLogger is a Logger that discards entries below a threshold and counts them per level.
+type DropCountingLogger interface {
+	Logger
+	// DropStats returns the number of entries dropped so far for each level that had any.
+	DropStats() map[LogLevel]int64
+}
+
+// dropCounts holds the per-level drop counters shared by a logger and the loggers derived from it.
+type dropCounts [TraceLevel + 1]atomic.Int64
+
+// dropCountingLogger attaches DropStats to the wrapped logger.
+type dropCountingLogger struct {
+	Logger
+	counts *dropCounts
+}
+
+// thresholdCore wraps a zapcore.Core and drops entries below min, counting them per level.
+type thresholdCore struct {
+	zapcore.Core
+	min    zapcore.Level
+	counts *dropCounts
+}
+
+// NewDropCountingLogger returns a logger that drops entries less severe than level and counts them,
+// so DropStats shows how much base would have emitted on top. Entries base itself disables are
+// neither written nor counted. The counters are shared with loggers derived from the result. Loggers not built by this package are not filtered and report no drops.
+func NewDropCountingLogger(base Logger, level LogLevel) DropCountingLogger {
+	counts := &dropCounts{}
+	l, ok := base.(*zapLogger)
+	min := convLevel(level)
+	if !ok || min == nil {
+		return &dropCountingLogger{Logger: base, counts: counts}
+	}
+
+	wrapped := l.wrapCore(func(core zapcore.Core) zapcore.Core {
+		return &thresholdCore{Core: core, min: *min, counts: counts}
+	})
+	return &dropCountingLogger{Logger: wrapped, counts: counts}
+}
+
+// DropStats returns the number of entries dropped so far for each level that had any.
+func (l *dropCountingLogger) DropStats() map[LogLevel]int64 {
+	stats := map[LogLevel]int64{}
+	for lvl := range l.counts {
+		if n := l.counts[lvl].Load(); n > 0 {
+			stats[LogLevel(lvl)] = n
+		}
+	}
+	return stats
+}
+
+// Enabled defers to the wrapped core, so only entries the base logger would have written reach
+// Check and are counted as drops.
+func (c *thresholdCore) Enabled(lvl zapcore.Level) bool {
+	return c.Core.Enabled(lvl)
+}
+
+// With preserves the threshold and counters on cores derived with additional fields.
+func (c *thresholdCore) With(fields []zapcore.Field) zapcore.Core {
+	return &thresholdCore{Core: c.Core.With(fields), min: c.min, counts: c.counts}
+}
+
+// Check counts and drops entries below the threshold and defers to the wrapped core otherwise.
+func (c *thresholdCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if ent.Level < c.min {
+		if c.Core.Enabled(ent.Level) {
+			c.counts[fromZapLevel(ent.Level)].Add(1)
+		}
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
+
+// Write counts and drops trace entries, which are written without a Check, below the threshold.
+func (c *thresholdCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if ent.Level < c.min {
+		c.counts[fromZapLevel(ent.Level)].Add(1)
+		return nil
+	}
+	return c.Core.Write(ent, fields)
+}
+
+// logCounters holds the process-wide counters incremented by loggers derived with WithCounter.
+var logCounters sync.Map // map[string]*atomic.Int64
+
+// WithCounter returns a logger that increments the process-wide counter name for every entry it
+// writes, in addition to writing it. Entries disabled by level are not counted. Loggers derived
+// from the result count as well, and loggers using the same name share the counter.
+func (l *zapLogger) WithCounter(name string) Logger {
+	v, _ := logCounters.LoadOrStore(name, new(atomic.Int64))
+	counter := v.(*atomic.Int64)
+	return l.wrapCore(func(core zapcore.Core) zapcore.Core {
+		return newTransformCore(core, func(ent zapcore.Entry, fields []zapcore.Field) (zapcore.Entry, []zapcore.Field) {
+			counter.Add(1)
+			return ent, fields
+		})
+	})
+}
+
+// Counters returns the current value of every counter registered with WithCounter.
+func Counters() map[string]int64 {
+	counters := map[string]int64{}
+	logCounters.Range(func(name, v interface{}) bool {
+		counters[name.(string)] = v.(*atomic.Int64).Load()
+		return true
+	})
+	return counters
+}
+
+// ResetCounters sets every counter registered with WithCounter back to zero, e.g. after publishing
+// their values. Loggers keep counting into the same counters.
+func ResetCounters() {
+	logCounters.Range(func(_, v interface{}) bool {
+		v.(*atomic.Int64).Store(0)
+		return true
+	})
+}
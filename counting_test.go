@@ -0,0 +1,72 @@
+package log
+
+import (
+	"strings"
+	"testing"
+)
+
+// Test NewDropCountingLogger to verify dropped entries are counted per level and not written
+func TestNewDropCountingLogger(t *testing.T) {
+	base, buf := newBufferedZap(t, &Config{IsJson: true}, DebugLevel)
+	logger := NewDropCountingLogger(base, InfoLevel)
+	derived := logger.WithField("request", 1)
+	for i := 0; i < 3; i++ {
+		derived.Debug("verbose")
+	}
+	logger.Info("kept")
+
+	stats := logger.DropStats()
+	if stats[DebugLevel] != 3 || len(stats) != 1 {
+		t.Errorf("Expected 3 debug drops, got %v", stats)
+	}
+	out := buf.String()
+	if strings.Contains(out, "verbose") || !strings.Contains(out, "kept") {
+		t.Errorf("Expected only the info entry to be written, got %q", out)
+	}
+}
+
+// Test NewDropCountingLogger to verify levels the base logger disables are reported as disabled and not
+// counted, while dropped Print entries are
+func TestNewDropCountingLogger_Levels(t *testing.T) {
+	base, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	logger := NewDropCountingLogger(base, WarnLevel)
+	if logger.Check(DebugLevel) {
+		t.Error("Expected debug to stay disabled")
+	}
+	logger.Debug("disabled")
+	logger.Info("dropped")
+	logger.Print("printed")
+
+	stats := logger.DropStats()
+	if stats[InfoLevel] != 1 || stats[TraceLevel] != 1 || len(stats) != 2 {
+		t.Errorf("Expected 1 info and 1 trace drop, got %v", stats)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected nothing to be written, got %q", buf.String())
+	}
+}
+
+// Test WithCounter to verify every written entry increments the named counter
+func TestZapLogger_WithCounter(t *testing.T) {
+	base, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	logger := base.WithCounter("test.checkout")
+	ResetCounters()
+
+	logger.Info("one")
+	logger.WithField("step", 2).Warn("two")
+	logger.Error("three")
+	logger.Debug("disabled")
+
+	if got := Counters()["test.checkout"]; got != 3 {
+		t.Errorf("Expected counter 3, got %d", got)
+	}
+	if n := strings.Count(buf.String(), "\n"); n != 3 {
+		t.Errorf("Expected the entries to be written as usual, got %q", buf.String())
+	}
+
+	ResetCounters()
+	logger.Info("four")
+	if got := Counters()["test.checkout"]; got != 1 {
+		t.Errorf("Expected counter 1 after ResetCounters, got %d", got)
+	}
+}
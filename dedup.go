@@ -0,0 +1,138 @@
+package log
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// dedupState is the pending entry shared by every core of a dedup logger.
+type dedupState struct {
+	mu      sync.Mutex
+	flush   time.Duration
+	timer   *time.Timer
+	pending *dedupEntry
+}
+
+// dedupEntry is an entry held back while identical entries are counted.
+type dedupEntry struct {
+	core    zapcore.Core // Core the entry was logged through, carrying its context fields.
+	context []zapcore.Field
+	ent     zapcore.Entry
+	fields  []zapcore.Field
+	count   int
+}
+
+// dedupCore wraps a zapcore.Core and collapses consecutive identical entries.
+type dedupCore struct {
+	zapcore.Core
+	state   *dedupState
+	context []zapcore.Field // Context fields added through With, part of an entry's identity.
+}
+
+// NewDedupLogger returns a logger that collapses consecutive entries with the same level, logger
+// name, message, context fields and entry fields into one line. The line is written when a different entry arrives or flush after the first one,
+// with a "repeated" field counting the occurrences when there was more than one. Panic and Fatal
+// entries are never held back. Loggers not built by this package are returned unchanged.
+func NewDedupLogger(l Logger, flush time.Duration) Logger {
+	zl, ok := l.(*zapLogger)
+	if !ok {
+		return l
+	}
+
+	state := &dedupState{flush: flush}
+	return zl.wrapCore(func(core zapcore.Core) zapcore.Core {
+		return &dedupCore{Core: core, state: state}
+	})
+}
+
+// With preserves the deduplication on cores derived with additional fields.
+func (c *dedupCore) With(fields []zapcore.Field) zapcore.Core {
+	context := append(c.context[:len(c.context):len(c.context)], fields...)
+	return &dedupCore{Core: c.Core.With(fields), state: c.state, context: context}
+}
+
+// Check registers the dedup core for entries the wrapped core would accept.
+func (c *dedupCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return checkWrapped(c.Core, c, ent, ce)
+}
+
+// Write counts an entry repeating the pending one, or writes the pending entry and holds back this one.
+func (c *dedupCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	s := c.state
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p := s.pending; p != nil && p.repeats(c.context, ent, fields) {
+		p.count++
+		return nil
+	}
+	err := s.writePending()
+	if ent.Level >= zapcore.DPanicLevel {
+		return multierr.Append(err, c.Core.Write(ent, fields))
+	}
+
+	p := &dedupEntry{core: c.Core, context: c.context, ent: ent, fields: append([]zapcore.Field(nil), fields...), count: 1}
+	s.pending = p
+	if s.flush > 0 {
+		s.timer = time.AfterFunc(s.flush, func() { s.flushPending(p) })
+	}
+	return err
+}
+
+// repeats reports whether an entry with the given context fields, entry and fields repeats p.
+func (p *dedupEntry) repeats(context []zapcore.Field, ent zapcore.Entry, fields []zapcore.Field) bool {
+	return p.ent.Level == ent.Level && p.ent.LoggerName == ent.LoggerName && p.ent.Message == ent.Message &&
+		equalFields(p.context, context) && equalFields(p.fields, fields)
+}
+
+// equalFields reports whether a and b hold equal fields in the same order.
+func equalFields(a, b []zapcore.Field) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equals(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Sync writes the pending entry before syncing the wrapped core.
+func (c *dedupCore) Sync() error {
+	c.state.mu.Lock()
+	err := c.state.writePending()
+	c.state.mu.Unlock()
+	return multierr.Append(err, c.Core.Sync())
+}
+
+// flushPending writes p when its flush timer fires, unless it was already written.
+func (s *dedupState) flushPending(p *dedupEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.pending == p {
+		_ = s.writePending()
+	}
+}
+
+// writePending writes and clears the pending entry. The caller must hold s.mu.
+func (s *dedupState) writePending() error {
+	p := s.pending
+	if p == nil {
+		return nil
+	}
+	s.pending = nil
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	fields := p.fields
+	if p.count > 1 {
+		fields = appendFields(fields, zap.Int("repeated", p.count))
+	}
+	return p.core.Write(p.ent, fields)
+}
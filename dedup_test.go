@@ -0,0 +1,74 @@
+package log
+
+import (
+	"testing"
+	"time"
+)
+
+// Test NewDedupLogger to verify identical consecutive entries collapse into one line with a count
+func TestNewDedupLogger(t *testing.T) {
+	base, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	logger := NewDedupLogger(base, time.Hour)
+	for i := 0; i < 5; i++ {
+		logger.Warn("retrying...")
+	}
+	logger.Info("connected")
+
+	entries := decodeEntries(t, buf.String())
+	if len(entries) != 1 || entries[0]["message"] != "retrying..." || entries[0]["repeated"] != float64(5) {
+		t.Fatalf("Expected the collapsed line before the new entry arrives, got %v", entries)
+	}
+
+	logger.WithField("peer", "db").Error("lost")
+	entries = decodeEntries(t, buf.String())
+	if len(entries) != 2 || entries[1]["message"] != "connected" {
+		t.Fatalf("Expected the single entry to be written, got %v", entries)
+	}
+	if _, ok := entries[1]["repeated"]; ok {
+		t.Errorf("Expected no repeated field on a single entry, got %v", entries[1])
+	}
+}
+
+// Test NewDedupLogger to verify the pending line is written when the flush timer fires
+func TestNewDedupLogger_Flush(t *testing.T) {
+	base, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	logger := NewDedupLogger(base, 10*time.Millisecond)
+	logger.Info("tick")
+	logger.Info("tick")
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		base.sink.mu.Lock()
+		out := buf.String()
+		base.sink.mu.Unlock()
+		if out != "" {
+			if entry := decodeEntries(t, out)[0]; entry["repeated"] != float64(2) {
+				t.Errorf("Expected repeated=2, got %v", entry)
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the pending entry to be flushed")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// Test NewDedupLogger to verify entries with the same message but different fields are not collapsed
+func TestNewDedupLogger_Fields(t *testing.T) {
+	base, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	logger := NewDedupLogger(base, time.Hour)
+	logger.WithField("user", "alice").Warn("login failed")
+	logger.WithField("user", "bob").Warn("login failed")
+	logger.WithField("user", "bob").Warn("login failed")
+	logger.Warnw("login failed", "user", "carol")
+	logger.Info("done")
+
+	entries := decodeEntries(t, buf.String())
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 lines, got %v", entries)
+	}
+	if entries[0]["user"] != "alice" || entries[1]["user"] != "bob" || entries[1]["repeated"] != float64(2) || entries[2]["user"] != "carol" {
+		t.Errorf("Expected one line per user with bob repeated, got %v", entries)
+	}
+}
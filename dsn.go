@@ -0,0 +1,77 @@
+package log
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// ParseDSN builds a Config from a URL-style value such as "log://stdout?level=info&json=true&color=false".
+// The target is "log://stdout", "log://stderr", "file:///path/to/file.log" or, for a path relative
+// to the working directory, "file:logs/app.log". A file DSN with a host such as "file://logs/app.log"
+// is rejected. Supported query parameters are level, json, color, host and pid; unknown parameters
+// are rejected.
+func ParseDSN(dsn string) (*Config, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := &Config{}
+	switch u.Scheme {
+	case "log":
+		switch u.Host {
+		case OutputStdout, OutputStderr:
+			conf.OutputPaths = []string{u.Host}
+		default:
+			return nil, fmt.Errorf("unsupported log DSN target %q", u.Host)
+		}
+	case "file":
+		if u.Host != "" {
+			return nil, fmt.Errorf("log DSN %q has host %q; use file:///path for absolute or file:relative/path for relative files", dsn, u.Host)
+		}
+		if u.Path == "" && u.Opaque != "" {
+			u.Path = u.Opaque
+		}
+		if u.Path == "" {
+			return nil, fmt.Errorf("log DSN %q has no file path", dsn)
+		}
+		conf.OutputPaths = []string{u.Path}
+	default:
+		return nil, fmt.Errorf("unsupported log DSN scheme %q", u.Scheme)
+	}
+
+	for key, values := range u.Query() {
+		value := values[len(values)-1]
+		switch key {
+		case "level":
+			conf.Level = value
+		case "json":
+			err = parseDSNBool(key, value, &conf.IsJson)
+		case "color":
+			var color bool
+			err = parseDSNBool(key, value, &color)
+			conf.DisableColor = !color
+		case "host":
+			err = parseDSNBool(key, value, &conf.IncludeHost)
+		case "pid":
+			err = parseDSNBool(key, value, &conf.IncludePID)
+		default:
+			err = fmt.Errorf("unsupported log DSN parameter %q", key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return conf, nil
+}
+
+// parseDSNBool parses the boolean DSN parameter key into dst.
+func parseDSNBool(key, value string, dst *bool) error {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("invalid log DSN parameter %s=%q", key, value)
+	}
+	*dst = b
+	return nil
+}
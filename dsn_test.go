@@ -0,0 +1,51 @@
+package log
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Test ParseDSN to verify representative DSNs are parsed into the expected Config
+func TestParseDSN(t *testing.T) {
+	tests := []struct {
+		dsn  string
+		want *Config
+	}{
+		{
+			dsn:  "log://stdout?level=info&json=true&color=false",
+			want: &Config{Level: "info", IsJson: true, DisableColor: true, OutputPaths: []string{"stdout"}},
+		},
+		{
+			dsn:  "log://stderr?color=true&host=1&pid=true",
+			want: &Config{IncludeHost: true, IncludePID: true, OutputPaths: []string{"stderr"}},
+		},
+		{
+			dsn:  "file:///var/log/app.log?level=debug",
+			want: &Config{Level: "debug", OutputPaths: []string{"/var/log/app.log"}},
+		},
+		{
+			dsn:  "file:logs/app.log?json=true",
+			want: &Config{IsJson: true, OutputPaths: []string{"logs/app.log"}},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDSN(tt.dsn)
+		if err != nil {
+			t.Errorf("ParseDSN(%q) returned error: %v", tt.dsn, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseDSN(%q) = %+v; want %+v", tt.dsn, got, tt.want)
+		}
+	}
+}
+
+// Test ParseDSN to verify unsupported targets and parameters are rejected
+func TestParseDSN_Invalid(t *testing.T) {
+	for _, dsn := range []string{"http://stdout", "log://syslog", "file://", "file://logs/app.log", "log://stdout?json=maybe", "log://stdout?format=xml"} {
+		if _, err := ParseDSN(dsn); err == nil {
+			t.Errorf("ParseDSN(%q) expected an error", dsn)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package log
+
+import (
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// withWriteField returns a logger that attaches the field built by fn to every entry,
+// evaluating fn when the entry is written rather than when the logger is derived.
+func (l *zapLogger) withWriteField(fn func() zapcore.Field) *zapLogger {
+	return l.wrapCore(func(core zapcore.Core) zapcore.Core {
+		return newTransformCore(core, func(ent zapcore.Entry, fields []zapcore.Field) (zapcore.Entry, []zapcore.Field) {
+			return ent, appendFields(fields, fn())
+		})
+	})
+}
+
+// WithTimer returns a logger adding an "elapsed_ms" field with the milliseconds elapsed since the call.
+func (l *zapLogger) WithTimer() Logger {
+	start := time.Now()
+	return l.withWriteField(func() zapcore.Field {
+		return zap.Float64("elapsed_ms", float64(time.Since(start))/float64(time.Millisecond))
+	})
+}
+
+// WithSequence returns a logger adding a "seq" field that starts at 1 and increases with every entry.
+// Loggers derived from the result share its counter, so their entries form one sequence.
+func (l *zapLogger) WithSequence() Logger {
+	var seq atomic.Uint64
+	return l.withWriteField(func() zapcore.Field {
+		return zap.Uint64("seq", seq.Add(1))
+	})
+}
+
+// WithDynamic returns a logger adding a key field whose value is produced by fn for every entry,
+// so it always reflects the current state, such as a queue depth, rather than a captured one.
+func (l *zapLogger) WithDynamic(key string, fn func() interface{}) Logger {
+	return l.withWriteField(func() zapcore.Field {
+		return zap.Any(key, fn())
+	})
+}
+
+// globalSeq numbers entries across every logger of the process that enables Config.GlobalSequence.
+var globalSeq atomic.Uint64
+
+// addGlobalSequence attaches the next process-wide sequence number as a "global_seq" field, apart from
+// the "seq" fields of WithSequence and NewAuditLogger.
+func addGlobalSequence(ent zapcore.Entry, fields []zapcore.Field) (zapcore.Entry, []zapcore.Field) {
+	return ent, appendFields(fields, zap.Uint64("global_seq", globalSeq.Add(1)))
+}
@@ -0,0 +1,108 @@
+package log
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Test WithTimer to verify sequential logs show increasing elapsed values
+func TestZapLogger_WithTimer(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	timed := logger.WithTimer()
+
+	timed.Info("first")
+	time.Sleep(2 * time.Millisecond)
+	timed.Info("second")
+
+	entries := decodeEntries(t, buf.String())
+	first, _ := entries[0]["elapsed_ms"].(float64)
+	second, _ := entries[1]["elapsed_ms"].(float64)
+	if second <= first || second < 2 {
+		t.Errorf("Expected increasing elapsed_ms of at least 2ms, got %v then %v", first, second)
+	}
+}
+
+// Test WithSequence to verify entries carry an increasing seq shared with derived loggers
+func TestZapLogger_WithSequence(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	sequenced := logger.WithSequence()
+	sequenced.Info("one")
+	sequenced.WithField("step", 2).Info("two")
+	sequenced.Info("three")
+	logger.Info("unsequenced")
+
+	entries := decodeEntries(t, buf.String())
+	for i, entry := range entries[:3] {
+		if entry["seq"] != float64(i+1) {
+			t.Errorf("Expected seq %d on %v", i+1, entry)
+		}
+	}
+	if _, ok := entries[3]["seq"]; ok {
+		t.Errorf("Expected no seq on the parent logger, got %v", entries[3])
+	}
+}
+
+// Test WithDynamic to verify each entry carries the current value of the closure
+func TestZapLogger_WithDynamic(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	depth := 3
+	dynamic := logger.WithDynamic("queue_depth", func() interface{} { return depth })
+
+	dynamic.Info("first")
+	depth = 7
+	dynamic.WithField("step", 2).Info("second")
+
+	entries := decodeEntries(t, buf.String())
+	if entries[0]["queue_depth"] != float64(3) || entries[1]["queue_depth"] != float64(7) {
+		t.Errorf("Expected the current depth on each entry, got %v and %v", entries[0], entries[1])
+	}
+}
+
+// Test GlobalSequence to verify concurrent entries get unique global_seq values increasing per goroutine
+func TestGlobalSequence(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true, GlobalSequence: true}, InfoLevel)
+	var wg sync.WaitGroup
+	for g := 0; g < 2; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				logger.Infow("tick", "goroutine", g)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	seen := map[float64]bool{}
+	last := map[float64]float64{}
+	for _, entry := range decodeEntries(t, buf.String()) {
+		seq, g := entry["global_seq"].(float64), entry["goroutine"].(float64)
+		if seen[seq] {
+			t.Fatalf("Duplicate seq %v", seq)
+		}
+		seen[seq] = true
+		if seq <= last[g] {
+			t.Errorf("Expected increasing seq for goroutine %v, got %v after %v", g, seq, last[g])
+		}
+		last[g] = seq
+	}
+	if len(seen) != 100 {
+		t.Errorf("Expected 100 entries, got %d", len(seen))
+	}
+}
+
+// Test GlobalSequence together with WithSequence to verify both numbers are kept under their own keys
+func TestGlobalSequence_WithSequence(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true, GlobalSequence: true}, InfoLevel)
+	logger.WithSequence().Info("first")
+
+	if n := strings.Count(buf.String(), `"seq"`); n != 1 {
+		t.Errorf("Expected a single seq field, got %q", buf.String())
+	}
+	entry := decodeEntry(t, buf)
+	if entry["seq"] != float64(1) || entry["global_seq"] == nil {
+		t.Errorf("Expected seq 1 and a global_seq field, got %v", entry)
+	}
+}
@@ -0,0 +1,71 @@
+package log
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ecsVersion is the ECS version the "ecs" encoding follows.
+const ecsVersion = "8.11.0"
+
+// ecsLevels maps zap levels to the level strings used by ECS consumers.
+var ecsLevels = map[zapcore.Level]string{
+	zapcore.DebugLevel - 1: "trace",
+	zapcore.DebugLevel:     "debug",
+	zapcore.InfoLevel:      "info",
+	zapcore.WarnLevel:      "warn",
+	zapcore.ErrorLevel:     "error",
+	zapcore.DPanicLevel:    "critical",
+	zapcore.PanicLevel:     "alert",
+	zapcore.FatalLevel:     "emergency",
+}
+
+// ecsEncoderConfig returns the encoder configuration used by the "ecs" encoding. The caller is
+// written by addECSOrigin as a structured "log.origin" object, so it is left out of the encoder.
+func ecsEncoderConfig(base zapcore.EncoderConfig) zapcore.EncoderConfig {
+	base.MessageKey = "message"
+	base.LevelKey = "log.level"
+	base.TimeKey = "@timestamp"
+	base.NameKey = "log.logger"
+	base.CallerKey = zapcore.OmitKey
+	base.EncodeLevel = ecsLevelEncoder
+	base.EncodeTime = ecsTimeEncoder
+	return base
+}
+
+// ecsLevelEncoder writes the ECS level string for l.
+func ecsLevelEncoder(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	if s, ok := ecsLevels[l]; ok {
+		enc.AppendString(s)
+		return
+	}
+	enc.AppendString(l.String())
+}
+
+// ecsTimeEncoder writes timestamps in UTC with millisecond precision, as expected for "@timestamp".
+func ecsTimeEncoder(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+	enc.AppendString(t.UTC().Format("2006-01-02T15:04:05.000Z07:00"))
+}
+
+// ecsOrigin renders the caller as the ECS "log.origin" object.
+type ecsOrigin zapcore.EntryCaller
+
+// MarshalLogObject writes the file name, line and function of the caller.
+func (o ecsOrigin) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("file.name", zapcore.EntryCaller(o).TrimmedPath())
+	enc.AddInt("file.line", o.Line)
+	if o.Function != "" {
+		enc.AddString("function", o.Function)
+	}
+	return nil
+}
+
+// addECSOrigin attaches the caller of the entry as a "log.origin" object.
+func addECSOrigin(ent zapcore.Entry, fields []zapcore.Field) (zapcore.Entry, []zapcore.Field) {
+	if !ent.Caller.Defined {
+		return ent, fields
+	}
+	return ent, appendFields(fields, zap.Object("log.origin", ecsOrigin(ent.Caller)))
+}
@@ -0,0 +1,54 @@
+package log
+
+import (
+	"testing"
+	"time"
+)
+
+// Test EncodingECS to verify entries follow the ECS field layout
+func TestEncodingECS(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{Encoding: EncodingECS}, InfoLevel)
+	logger.Named("api").Warnw("slow request", "duration", 3)
+
+	entry := decodeEntry(t, buf)
+	ts, ok := entry["@timestamp"].(string)
+	if !ok {
+		t.Fatalf("Expected @timestamp string, got %v", entry["@timestamp"])
+	}
+	if _, err := time.Parse(time.RFC3339Nano, ts); err != nil {
+		t.Errorf("Expected RFC 3339 @timestamp, got %q: %v", ts, err)
+	}
+	expected := map[string]interface{}{
+		"log.level":   "warn",
+		"message":     "slow request",
+		"log.logger":  "api",
+		"ecs.version": ecsVersion,
+		"duration":    float64(3),
+	}
+	for key, want := range expected {
+		if entry[key] != want {
+			t.Errorf("Expected %s %v, got %v", key, want, entry[key])
+		}
+	}
+	origin, ok := entry["log.origin"].(map[string]interface{})
+	if !ok || origin["file.name"] == nil || origin["file.line"] == nil {
+		t.Errorf("Expected log.origin with file name and line, got %v", entry["log.origin"])
+	}
+	for _, key := range []string{"severity", "timestamp", "module"} {
+		if _, ok := entry[key]; ok {
+			t.Errorf("Expected no %q key in ECS output", key)
+		}
+	}
+}
+
+// Test ecsLevelEncoder to verify our levels map to ECS level strings
+func TestECSLevels(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{Encoding: EncodingECS}, TraceLevel)
+	logger.Trace("fine")
+	logger.Error("failed")
+
+	entries := decodeEntries(t, buf.String())
+	if len(entries) != 2 || entries[0]["log.level"] != "trace" || entries[1]["log.level"] != "error" {
+		t.Errorf("Expected trace and error levels, got %v", entries)
+	}
+}
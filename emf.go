@@ -0,0 +1,140 @@
+package log
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// defaultMetricNamespace is the CloudWatch namespace used when Config.MetricNamespace is unset.
+const defaultMetricNamespace = "aws-embedded-metrics"
+
+// metricValue is the value of a field added with WithMetric. Outside the EMF encoding it is
+// rendered as the bare number.
+type metricValue struct {
+	value float64
+	unit  string
+}
+
+// MarshalJSON renders the metric as its value.
+func (m metricValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.value)
+}
+
+// WithMetric attaches a metric named name. With the "emf" encoding every entry becomes a CloudWatch
+// Embedded Metric Format document publishing it; otherwise the value is logged as a plain field.
+// unit is a CloudWatch unit such as "Milliseconds" or "Count" and defaults to "None".
+func (l *zapLogger) WithMetric(name string, value float64, unit string) Logger {
+	if unit == "" {
+		unit = "None"
+	}
+	return l.withFields(zap.Any(name, metricValue{value, unit}))
+}
+
+// emfMetric is a metric definition in the "_aws" metadata block.
+type emfMetric struct {
+	name string
+	unit string
+}
+
+// emfCore wraps a zapcore.Core and turns entries carrying metrics into CloudWatch Embedded Metric
+// Format documents: metric fields become numbers and an "_aws" block declares them. Like truncateCore
+// it also rewrites the context fields passed to With, remembering the metrics they declare.
+type emfCore struct {
+	zapcore.Core
+	namespace string
+	metrics   []emfMetric
+}
+
+// newEMFCore wraps core for the given namespace, defaulting to defaultMetricNamespace.
+func newEMFCore(core zapcore.Core, namespace string) zapcore.Core {
+	if namespace == "" {
+		namespace = defaultMetricNamespace
+	}
+	return &emfCore{Core: core, namespace: namespace}
+}
+
+// With converts the metric context fields and keeps their definitions for later entries.
+func (c *emfCore) With(fields []zapcore.Field) zapcore.Core {
+	fields, found := extractMetrics(fields)
+	metrics := append(c.metrics[:len(c.metrics):len(c.metrics)], found...)
+	return &emfCore{Core: c.Core.With(fields), namespace: c.namespace, metrics: metrics}
+}
+
+// Check registers the EMF core for entries the wrapped core would accept.
+func (c *emfCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return checkWrapped(c.Core, c, ent, ce)
+}
+
+// Write converts the metric fields of the entry and adds the "_aws" block when any metric applies.
+func (c *emfCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	fields, found := extractMetrics(fields)
+	metrics := append(c.metrics[:len(c.metrics):len(c.metrics)], found...)
+	if len(metrics) > 0 {
+		fields = appendFields(fields, zap.Object("_aws", emfMetadata{ent.Time, c.namespace, metrics}))
+	}
+	return c.Core.Write(ent, fields)
+}
+
+// extractMetrics replaces the metric fields in fields with their numeric values and returns their
+// definitions. The caller's slice is copied only when it holds a metric.
+func extractMetrics(fields []zapcore.Field) ([]zapcore.Field, []emfMetric) {
+	var out []zapcore.Field
+	var metrics []emfMetric
+	for i, f := range fields {
+		m, ok := f.Interface.(metricValue)
+		if !ok || f.Type != zapcore.ReflectType {
+			continue
+		}
+		if out == nil {
+			out = append([]zapcore.Field(nil), fields...)
+		}
+		out[i] = zap.Float64(f.Key, m.value)
+		metrics = append(metrics, emfMetric{f.Key, m.unit})
+	}
+	if out == nil {
+		return fields, nil
+	}
+	return out, metrics
+}
+
+// emfMetadata is the "_aws" block of an Embedded Metric Format document.
+type emfMetadata struct {
+	time      time.Time
+	namespace string
+	metrics   []emfMetric
+}
+
+// MarshalLogObject writes the timestamp and a single metric directive without dimensions.
+func (m emfMetadata) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt64("Timestamp", m.time.UnixMilli())
+	return enc.AddArray("CloudWatchMetrics", zapcore.ArrayMarshalerFunc(func(arr zapcore.ArrayEncoder) error {
+		return arr.AppendObject(zapcore.ObjectMarshalerFunc(m.marshalDirective))
+	}))
+}
+
+// marshalDirective writes the namespace, an empty dimension set and the metric definitions.
+func (m emfMetadata) marshalDirective(enc zapcore.ObjectEncoder) error {
+	enc.AddString("Namespace", m.namespace)
+	err := enc.AddArray("Dimensions", zapcore.ArrayMarshalerFunc(func(arr zapcore.ArrayEncoder) error {
+		return arr.AppendArray(zapcore.ArrayMarshalerFunc(func(zapcore.ArrayEncoder) error { return nil }))
+	}))
+	if err != nil {
+		return err
+	}
+	return enc.AddArray("Metrics", zapcore.ArrayMarshalerFunc(func(arr zapcore.ArrayEncoder) error {
+		for _, metric := range m.metrics {
+			err := arr.AppendObject(zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+				enc.AddString("Name", metric.name)
+				enc.AddString("Unit", metric.unit)
+				return nil
+			}))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+}
@@ -0,0 +1,57 @@
+package log
+
+import (
+	"testing"
+)
+
+// Test the emf encoding to verify entries with metrics carry a valid _aws.CloudWatchMetrics block
+func TestEncodingEMF(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{Encoding: EncodingEMF, MetricNamespace: "checkout"}, InfoLevel)
+	logger.WithField("order", "o-1").WithMetric("Latency", 12.5, "Milliseconds").
+		WithMetric("Items", 3, "").Info("order placed")
+
+	entry := decodeEntry(t, buf)
+	if entry["Latency"] != 12.5 || entry["Items"] != float64(3) || entry["order"] != "o-1" {
+		t.Errorf("Expected metric values and context fields, got %v", entry)
+	}
+	aws, _ := entry["_aws"].(map[string]interface{})
+	if _, ok := aws["Timestamp"].(float64); !ok {
+		t.Fatalf("Expected a numeric _aws.Timestamp, got %v", entry["_aws"])
+	}
+	directives, _ := aws["CloudWatchMetrics"].([]interface{})
+	if len(directives) != 1 {
+		t.Fatalf("Expected one metric directive, got %v", aws["CloudWatchMetrics"])
+	}
+	directive := directives[0].(map[string]interface{})
+	if directive["Namespace"] != "checkout" {
+		t.Errorf("Expected namespace %q, got %v", "checkout", directive["Namespace"])
+	}
+	if dims, _ := directive["Dimensions"].([]interface{}); len(dims) != 1 {
+		t.Errorf("Expected one dimension set, got %v", directive["Dimensions"])
+	}
+	metrics, _ := directive["Metrics"].([]interface{})
+	if len(metrics) != 2 {
+		t.Fatalf("Expected two metric definitions, got %v", directive["Metrics"])
+	}
+	if m := metrics[0].(map[string]interface{}); m["Name"] != "Latency" || m["Unit"] != "Milliseconds" {
+		t.Errorf("Expected the Latency definition, got %v", m)
+	}
+	if m := metrics[1].(map[string]interface{}); m["Name"] != "Items" || m["Unit"] != "None" {
+		t.Errorf("Expected the Items definition with the default unit, got %v", m)
+	}
+}
+
+// Test WithMetric to verify entries without metrics have no _aws block and other encodings log the bare value
+func TestWithMetric_Plain(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{Encoding: EncodingEMF}, InfoLevel)
+	logger.Info("no metrics")
+	if entry := decodeEntry(t, buf); entry["_aws"] != nil {
+		t.Errorf("Expected no _aws block, got %v", entry)
+	}
+
+	logger, buf = newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	logger.WithMetric("Latency", 7, "Milliseconds").Info("json")
+	if entry := decodeEntry(t, buf); entry["Latency"] != float64(7) || entry["_aws"] != nil {
+		t.Errorf("Expected a plain metric value, got %v", entry)
+	}
+}
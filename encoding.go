@@ -0,0 +1,50 @@
+package log
+
+import (
+	"go.uber.org/zap/zapcore"
+)
+
+// Encoding selects a preset output layout on top of the IsJson switch.
+type Encoding string
+
+const (
+	// EncodingECS writes JSON following the Elastic Common Schema, with "@timestamp", "log.level",
+	// "message", "log.logger", "log.origin" and "ecs.version" fields.
+	EncodingECS Encoding = "ecs"
+	// EncodingPlain writes console lines without colors: timestamp, level, caller and message.
+	EncodingPlain Encoding = "plain"
+	// EncodingEMF writes JSON in which entries carrying metrics added with WithMetric are
+	// CloudWatch Embedded Metric Format documents, in the namespace set by Config.MetricNamespace.
+	EncodingEMF Encoding = "emf"
+	// EncodingGCP writes JSON for Google Cloud Logging, with uppercase "severity" values and the trace
+	// id set by WithTraceID in "logging.googleapis.com/trace", qualified by Config.GCPProject.
+	EncodingGCP Encoding = "gcp"
+)
+
+// DurationFormat selects how time.Duration fields are rendered.
+type DurationFormat string
+
+const (
+	// DurationString renders durations as strings such as "1.5s". It is the default.
+	DurationString DurationFormat = "string"
+	// DurationSeconds renders durations as float seconds.
+	DurationSeconds DurationFormat = "seconds"
+	// DurationMillis renders durations as float milliseconds.
+	DurationMillis DurationFormat = "millis"
+	// DurationNanos renders durations as integer nanoseconds.
+	DurationNanos DurationFormat = "nanos"
+)
+
+// durationEncoder returns the zapcore.DurationEncoder for format.
+func durationEncoder(format DurationFormat) zapcore.DurationEncoder {
+	switch format {
+	case DurationSeconds:
+		return zapcore.SecondsDurationEncoder
+	case DurationMillis:
+		return zapcore.MillisDurationEncoder
+	case DurationNanos:
+		return zapcore.NanosDurationEncoder
+	default:
+		return zapcore.StringDurationEncoder
+	}
+}
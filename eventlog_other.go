@@ -0,0 +1,14 @@
+//go:build !windows
+
+package log
+
+import (
+	"errors"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// newEventLogCore reports that the Windows Event Log is unavailable on this platform.
+func newEventLogCore(source string, enc zapcore.Encoder, enab zapcore.LevelEnabler) (zapcore.Core, error) {
+	return nil, errors.New("event log output is only supported on windows")
+}
@@ -0,0 +1,78 @@
+//go:build windows
+
+package log
+
+import (
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/sys/windows/svc/eventlog"
+)
+
+// eventLogID is the event identifier used for every entry written to the Windows Event Log.
+const eventLogID = 1
+
+// eventWriter is the subset of *eventlog.Log used by eventLogCore.
+type eventWriter interface {
+	Info(eid uint32, msg string) error
+	Warning(eid uint32, msg string) error
+	Error(eid uint32, msg string) error
+}
+
+// eventLogCore is a zapcore.Core that reports entries to the Windows Event Log,
+// mapping Error and above to Error events, Warn to Warning and the rest to Info.
+type eventLogCore struct {
+	zapcore.LevelEnabler
+	enc zapcore.Encoder
+	out eventWriter
+}
+
+// newEventLogCore opens the event log for source and returns a core writing to it.
+func newEventLogCore(source string, enc zapcore.Encoder, enab zapcore.LevelEnabler) (zapcore.Core, error) {
+	el, err := eventlog.Open(source)
+	if err != nil {
+		return nil, err
+	}
+	return &eventLogCore{LevelEnabler: enab, enc: enc, out: el}, nil
+}
+
+// With returns a copy of the core with the fields encoded into its context.
+func (c *eventLogCore) With(fields []zapcore.Field) zapcore.Core {
+	enc := c.enc.Clone()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	return &eventLogCore{LevelEnabler: c.LevelEnabler, enc: enc, out: c.out}
+}
+
+// Check adds the core to the checked entry if its level is enabled.
+func (c *eventLogCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write encodes the entry and reports it with the event type matching its level.
+func (c *eventLogCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	msg := strings.TrimSuffix(buf.String(), "\n")
+	buf.Free()
+
+	switch {
+	case ent.Level >= zapcore.ErrorLevel:
+		return c.out.Error(eventLogID, msg)
+	case ent.Level == zapcore.WarnLevel:
+		return c.out.Warning(eventLogID, msg)
+	default:
+		return c.out.Info(eventLogID, msg)
+	}
+}
+
+// Sync is a no-op as event log writes are not buffered.
+func (c *eventLogCore) Sync() error {
+	return nil
+}
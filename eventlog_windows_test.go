@@ -0,0 +1,55 @@
+//go:build windows
+
+package log
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// fakeEventWriter records the event type of each reported entry
+type fakeEventWriter struct {
+	events []string
+}
+
+func (f *fakeEventWriter) Info(eid uint32, msg string) error {
+	f.events = append(f.events, "info")
+	return nil
+}
+
+func (f *fakeEventWriter) Warning(eid uint32, msg string) error {
+	f.events = append(f.events, "warning")
+	return nil
+}
+
+func (f *fakeEventWriter) Error(eid uint32, msg string) error {
+	f.events = append(f.events, "error")
+	return nil
+}
+
+// Test eventLogCore to verify an error log produces an Error event
+func TestEventLogCore_Error(t *testing.T) {
+	out := &fakeEventWriter{}
+	core := &eventLogCore{
+		LevelEnabler: zap.DebugLevel,
+		enc:          zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		out:          out,
+	}
+	logger := &zapLogger{log: *zap.New(core).Sugar()}
+
+	logger.Error("boom")
+	logger.Warn("careful")
+	logger.Debug("details")
+
+	want := []string{"error", "warning", "info"}
+	if len(out.events) != len(want) {
+		t.Fatalf("Expected events %v, got %v", want, out.events)
+	}
+	for i := range want {
+		if out.events[i] != want[i] {
+			t.Errorf("Event %d = %v; want %v", i, out.events[i], want[i])
+		}
+	}
+}
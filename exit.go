@@ -0,0 +1,98 @@
+package log
+
+import (
+	"os"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// FatalAction selects what happens after an entry at the fatal threshold is written.
+type FatalAction string
+
+const (
+	// FatalActionExit terminates the process through the exit function. It is the default.
+	FatalActionExit FatalAction = "exit"
+	// FatalActionPanic panics instead, so deferred cleanup runs and tests can recover.
+	FatalActionPanic FatalAction = "panic"
+)
+
+// defaultExitCode is the exit code used by Fatal when Config.FatalExitCode is unset.
+const defaultExitCode = 1
+
+// exitFunc terminates the process after a Fatal entry; it can be replaced with SetExitFunc.
+var exitFunc = os.Exit
+
+// SetExitFunc replaces the function used to terminate the process after a Fatal entry.
+// Passing nil restores os.Exit. It is mostly useful in tests and for custom shutdown handling.
+func SetExitFunc(fn func(code int)) {
+	if fn == nil {
+		fn = os.Exit
+	}
+	exitFunc = fn
+}
+
+// exitHook is a zapcore.CheckWriteHook that calls exitFunc with a fixed code after a Fatal entry.
+type exitHook struct {
+	code int
+}
+
+// OnWrite terminates the process with the configured exit code.
+func (h exitHook) OnWrite(*zapcore.CheckedEntry, []zapcore.Field) {
+	exitFunc(h.code)
+}
+
+// fatalHook returns the hook run after fatal entries according to conf.
+func fatalHook(conf *Config) zapcore.CheckWriteHook {
+	if conf.FatalAction == FatalActionPanic {
+		return zapcore.WriteThenPanic
+	}
+	code := conf.FatalExitCode
+	if code == 0 {
+		code = defaultExitCode
+	}
+	return exitHook{code}
+}
+
+// fatalLevelCore wraps a zapcore.Core and runs hook after entries at or above min, making levels
+// below Fatal behave like Fatal. Panic and Fatal entries keep the hooks set by the zap logger.
+type fatalLevelCore struct {
+	zapcore.Core
+	min  zapcore.Level
+	hook zapcore.CheckWriteHook
+}
+
+// With preserves the threshold on cores derived with additional fields.
+func (c *fatalLevelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &fatalLevelCore{Core: c.Core.With(fields), min: c.min, hook: c.hook}
+}
+
+// Check attaches the hook to accepted entries at or above the threshold.
+func (c *fatalLevelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	ce = c.Core.Check(ent, ce)
+	if ce != nil && ent.Level >= c.min && ent.Level < zapcore.DPanicLevel {
+		ce = ce.After(ent, c.hook)
+	}
+	return ce
+}
+
+// callbackHook is a zapcore.CheckWriteHook passing the entry message to a callback.
+type callbackHook struct {
+	fn func(msg string)
+}
+
+// OnWrite calls the callback with the message of the written entry.
+func (h callbackHook) OnWrite(ce *zapcore.CheckedEntry, _ []zapcore.Field) {
+	h.fn(ce.Message)
+}
+
+// NewGracefulLogger returns a logger whose Fatal methods call onFatal with the formatted message
+// after the entry is written, instead of exiting, so the application can drain and shut down itself.
+// Fatal returns normally afterwards. Loggers not built by this package are returned unchanged.
+func NewGracefulLogger(base Logger, onFatal func(msg string)) Logger {
+	l, ok := base.(*zapLogger)
+	if !ok {
+		return base
+	}
+	return l.withOptions(zap.WithFatalHook(callbackHook{onFatal}))
+}
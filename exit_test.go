@@ -0,0 +1,83 @@
+package log
+
+import (
+	"testing"
+)
+
+// Test FatalExitCode to verify Fatal passes the configured code to the exit function
+func TestFatalExitCode(t *testing.T) {
+	t.Cleanup(func() { SetExitFunc(nil) })
+
+	var got []int
+	SetExitFunc(func(code int) { got = append(got, code) })
+
+	custom, _ := newBufferedZap(t, &Config{IsJson: true, FatalExitCode: 3}, InfoLevel)
+	custom.Fatal("custom code")
+	standard, _ := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	standard.Fatalf("default %s", "code")
+
+	if len(got) != 2 || got[0] != 3 || got[1] != defaultExitCode {
+		t.Errorf("Expected exit codes [3 %d], got %v", defaultExitCode, got)
+	}
+}
+
+// Test FatalAction to verify Fatal panics instead of calling the exit function
+func TestFatalActionPanic(t *testing.T) {
+	t.Cleanup(func() { SetExitFunc(nil) })
+	exited := false
+	SetExitFunc(func(int) { exited = true })
+
+	logger, buf := newBufferedZap(t, &Config{IsJson: true, FatalAction: FatalActionPanic}, InfoLevel)
+	func() {
+		defer func() {
+			if r := recover(); r != "fatal failure" {
+				t.Errorf("Expected panic with the message, got %v", r)
+			}
+		}()
+		logger.Fatal("fatal failure")
+	}()
+
+	if exited {
+		t.Error("Expected the exit function not to be called")
+	}
+	if entry := decodeEntry(t, buf); entry["severity"] != "fatal" {
+		t.Errorf("Expected the fatal entry to be written first, got %v", entry)
+	}
+}
+
+// Test ExitOnLevel to verify Error entries become fatal while Warn entries are unaffected
+func TestExitOnLevel(t *testing.T) {
+	t.Cleanup(func() { SetExitFunc(nil) })
+	var got []int
+	SetExitFunc(func(code int) { got = append(got, code) })
+
+	logger, _ := newBufferedZap(t, &Config{IsJson: true, ExitOnLevel: ErrorLevel, FatalExitCode: 4}, InfoLevel)
+	logger.Warn("still running")
+	logger.WithField("strict", true).Error("strict failure")
+
+	if len(got) != 1 || got[0] != 4 {
+		t.Errorf("Expected a single exit with code 4, got %v", got)
+	}
+}
+
+// Test NewGracefulLogger to verify Fatal calls the callback with the message instead of exiting
+func TestNewGracefulLogger(t *testing.T) {
+	t.Cleanup(func() { SetExitFunc(nil) })
+	exited := false
+	SetExitFunc(func(int) { exited = true })
+
+	base, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	var messages []string
+	logger := NewGracefulLogger(base, func(msg string) { messages = append(messages, msg) })
+	logger.WithField("listener", ":8080").Fatalf("cannot bind %s", "port")
+
+	if exited {
+		t.Error("Expected the exit function not to be called")
+	}
+	if len(messages) != 1 || messages[0] != "cannot bind port" {
+		t.Errorf("Expected onFatal with the formatted message, got %v", messages)
+	}
+	if entry := decodeEntry(t, buf); entry["severity"] != "fatal" || entry["listener"] != ":8080" {
+		t.Errorf("Expected the fatal entry to be written first, got %v", entry)
+	}
+}
@@ -0,0 +1,119 @@
+package log
+
+import (
+	"go.uber.org/zap/zapcore"
+)
+
+// Merge returns a logger carrying the context fields of both a and b, with b winning on key conflicts.
+// The result writes through a's output and settings. Fields of loggers not built by this package
+// cannot be inspected, so if either input is such a logger the other one is returned unchanged.
+func Merge(a, b Logger) Logger {
+	za, ok := a.(*zapLogger)
+	if !ok || za.root == nil {
+		return b
+	}
+	zb, ok := b.(*zapLogger)
+	if !ok {
+		return a
+	}
+
+	merged := *za
+	merged.fields = mergeFields(za.fields, zb.fields)
+	merged.setLog(za.root.With(merged.fields...))
+	return &merged
+}
+
+// Reset returns a logger writing through the same core, name and level but without the context
+// fields accumulated through With and related methods. Per-entry fields such as WithTimer and
+// prefixes from WithPrefix belong to the core and are kept, as are the Config base fields.
+// The trace id attached through WithTraceID or a context is a context field and is dropped too.
+func (l *zapLogger) Reset() Logger {
+	if l.root == nil {
+		return l
+	}
+	c := *l
+	c.fields = nil
+	c.traceID = ""
+	c.setLog(l.root)
+	return &c
+}
+
+// fieldItem is a single context field, either a key-value pair or a zap.Field.
+type fieldItem struct {
+	key  string
+	args []interface{}
+}
+
+// splitFields groups sugared context arguments into individual fields.
+// Arguments that are neither zap.Fields nor string-keyed pairs are kept with an empty key.
+func splitFields(kvs []interface{}) []fieldItem {
+	var items []fieldItem
+	for i := 0; i < len(kvs); i++ {
+		switch key := kvs[i].(type) {
+		case zapcore.Field:
+			items = append(items, fieldItem{key.Key, kvs[i : i+1]})
+		case string:
+			if i+1 < len(kvs) {
+				items = append(items, fieldItem{key, kvs[i : i+2]})
+				i++
+				continue
+			}
+			items = append(items, fieldItem{"", kvs[i : i+1]})
+		default:
+			items = append(items, fieldItem{"", kvs[i : i+1]})
+		}
+	}
+	return items
+}
+
+// mergeFields returns the fields of a not overridden by b, followed by the fields of b.
+func mergeFields(a, b []interface{}) []interface{} {
+	bItems := splitFields(b)
+	override := make(map[string]bool, len(bItems))
+	for _, item := range bItems {
+		if item.key != "" {
+			override[item.key] = true
+		}
+	}
+
+	merged := make([]interface{}, 0, len(a)+len(b))
+	for _, item := range splitFields(a) {
+		if !override[item.key] || item.key == "" {
+			merged = append(merged, item.args...)
+		}
+	}
+	return append(merged, b...)
+}
+
+// FieldSet is a reusable, immutable bundle of context fields, such as the location fields attached
+// by every service in a region. Attach it with Logger.WithSet.
+type FieldSet struct {
+	kvs []interface{}
+}
+
+// NewFieldSet returns a FieldSet holding kvs, given as key-value pairs or zap.Fields like With.
+func NewFieldSet(kvs ...interface{}) FieldSet {
+	return FieldSet{append([]interface{}(nil), kvs...)}
+}
+
+// With returns a copy of the set with kvs added, replacing fields of the same key.
+func (s FieldSet) With(kvs ...interface{}) FieldSet {
+	return FieldSet{mergeFields(s.kvs, kvs)}
+}
+
+// Union returns a set with the fields of s and others, later sets winning on key conflicts.
+func (s FieldSet) Union(others ...FieldSet) FieldSet {
+	kvs := s.kvs
+	for _, o := range others {
+		kvs = mergeFields(kvs, o.kvs)
+	}
+	return FieldSet{append([]interface{}(nil), kvs...)}
+}
+
+// WithSet attaches every field of set to the logger.
+func (l *zapLogger) WithSet(set FieldSet) Logger {
+	if len(set.kvs) == 0 {
+		return l
+	}
+	return l.withFields(set.kvs...)
+}
@@ -0,0 +1,78 @@
+package log
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// Test Merge to verify the merged logger carries fields from both inputs with b winning conflicts
+func TestMerge(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	a := logger.WithField("request_id", "r-1").WithField("shared", "a")
+	b := logger.With(zap.String("job_id", "j-2")).WithField("shared", "b")
+
+	Merge(a, b).Info("merged")
+
+	entry := decodeEntry(t, buf)
+	if entry["request_id"] != "r-1" || entry["job_id"] != "j-2" {
+		t.Errorf("Expected fields from both loggers, got %v", entry)
+	}
+	if entry["shared"] != "b" || strings.Count(buf.String(), `"shared"`) != 1 {
+		t.Errorf("Expected conflicting field resolved in favour of b, got %s", buf.String())
+	}
+}
+
+// Test Merge to verify loggers not built by the package are passed through
+func TestMerge_Foreign(t *testing.T) {
+	logger := newZapSome()
+	mock := &MockLogger{}
+	if Merge(mock, logger) != logger || Merge(logger, mock) != logger {
+		t.Error("Expected Merge to return the zap-backed logger when the other is foreign")
+	}
+}
+
+// Test WithSet to verify all fields of composed sets are attached with later sets winning conflicts
+func TestWithSet(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	location := NewFieldSet("dc", "fra1", "region", "eu-central")
+	zone := NewFieldSet(zap.String("az", "eu-central-1a")).With("region", "eu-central-1")
+
+	logger.WithSet(location.Union(zone)).Info("placed")
+
+	entry := decodeEntry(t, buf)
+	if entry["dc"] != "fra1" || entry["az"] != "eu-central-1a" || entry["region"] != "eu-central-1" {
+		t.Errorf("Expected all set fields with the later region, got %v", entry)
+	}
+	if strings.Count(buf.String(), `"region"`) != 1 {
+		t.Errorf("Expected a single region field, got %s", buf.String())
+	}
+}
+
+// Test Reset to verify accumulated context fields are dropped while the name is kept
+func TestZapLogger_Reset(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true, IncludePID: true}, InfoLevel)
+	logger.Named("worker").WithField("a", 1).Reset().WithField("b", 2).Info("fresh")
+
+	entry := decodeEntry(t, buf)
+	if _, ok := entry["a"]; ok {
+		t.Errorf("Expected field a to be cleared, got %v", entry)
+	}
+	if entry["b"] != float64(2) || entry["logger"] != "worker" || entry["pid"] == nil {
+		t.Errorf("Expected the new field, the name and the base fields, got %v", entry)
+	}
+}
+
+// Test Reset to verify the trace id is dropped with the fields, so a later context id is attached again
+func TestZapLogger_ResetTraceID(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	ctx := ContextWithTraceID(context.Background(), "4bf92f3577b34da6a3ce929d0e0e4736")
+
+	logger.WithContext(ctx).Reset().WithContext(ctx).Info("traced")
+	entry := decodeEntry(t, buf)
+	if entry["trace_id"] != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Expected the trace id after Reset, got %v", entry)
+	}
+}
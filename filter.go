@@ -0,0 +1,61 @@
+package log
+
+import (
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// DropFilter reports whether an entry with the given level and message should be discarded.
+type DropFilter func(level LogLevel, msg string) bool
+
+var (
+	dropFiltersMu sync.RWMutex
+	dropFilters   []DropFilter // Registered filters, consulted by every logger built by the package.
+)
+
+// AddDropFilter registers a predicate used to suppress matching entries across all loggers.
+// Filters compose with OR semantics: an entry is dropped as soon as any filter returns true.
+func AddDropFilter(f DropFilter) {
+	dropFiltersMu.Lock()
+	defer dropFiltersMu.Unlock()
+	dropFilters = append(dropFilters, f)
+}
+
+// shouldDrop reports whether any registered filter matches the entry.
+func shouldDrop(level LogLevel, msg string) bool {
+	dropFiltersMu.RLock()
+	defer dropFiltersMu.RUnlock()
+	for _, f := range dropFilters {
+		if f(level, msg) {
+			return true
+		}
+	}
+	return false
+}
+
+// dropCore wraps a zapcore.Core and discards entries matched by the registered drop filters.
+type dropCore struct {
+	zapcore.Core
+}
+
+// With preserves the drop filtering on cores derived with additional fields.
+func (c *dropCore) With(fields []zapcore.Field) zapcore.Core {
+	return &dropCore{c.Core.With(fields)}
+}
+
+// Check skips the wrapped core when the entry matches a drop filter.
+func (c *dropCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if shouldDrop(fromZapLevel(ent.Level), ent.Message) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
+
+// Write discards entries matching a drop filter, including trace entries written without a Check.
+func (c *dropCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if shouldDrop(fromZapLevel(ent.Level), ent.Message) {
+		return nil
+	}
+	return c.Core.Write(ent, fields)
+}
@@ -0,0 +1,50 @@
+package log
+
+import (
+	"strings"
+	"testing"
+)
+
+// Test AddDropFilter to verify matching entries are dropped while others pass through
+func TestAddDropFilter(t *testing.T) {
+	saved := dropFilters
+	t.Cleanup(func() { dropFilters = saved })
+
+	AddDropFilter(func(level LogLevel, msg string) bool {
+		return level == WarnLevel && strings.Contains(msg, "deprecated API")
+	})
+	AddDropFilter(func(level LogLevel, msg string) bool {
+		return msg == "noise"
+	})
+
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	logger.Warn("call to deprecated API")
+	logger.Info("noise")
+	logger.Warn("disk almost full")
+
+	out := buf.String()
+	if strings.Contains(out, "deprecated API") || strings.Contains(out, "noise") {
+		t.Errorf("Expected filtered messages to be dropped, got %q", out)
+	}
+	if !strings.Contains(out, "disk almost full") {
+		t.Errorf("Expected unfiltered message to pass through, got %q", out)
+	}
+}
+
+// Test AddDropFilter to verify trace and Print entries, which are written without a Check, are filtered too
+func TestAddDropFilter_Trace(t *testing.T) {
+	saved := dropFilters
+	t.Cleanup(func() { dropFilters = saved })
+	AddDropFilter(func(level LogLevel, msg string) bool {
+		return msg == "noise"
+	})
+
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, TraceLevel)
+	logger.Trace("noise")
+	logger.Print("noise")
+	logger.Print("kept")
+
+	if out := buf.String(); strings.Contains(out, "noise") || !strings.Contains(out, "kept") {
+		t.Errorf("Expected filtered trace lines to be dropped, got %q", out)
+	}
+}
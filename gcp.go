@@ -0,0 +1,86 @@
+package log
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// gcpTraceKey is the field Google Cloud Logging uses to correlate entries with a trace.
+const gcpTraceKey = "logging.googleapis.com/trace"
+
+// gcpSeverities maps zap levels to Google Cloud Logging severities.
+var gcpSeverities = map[zapcore.Level]string{
+	zapcore.DebugLevel - 1: "DEBUG",
+	zapcore.DebugLevel:     "DEBUG",
+	zapcore.InfoLevel:      "INFO",
+	zapcore.WarnLevel:      "WARNING",
+	zapcore.ErrorLevel:     "ERROR",
+	zapcore.DPanicLevel:    "CRITICAL",
+	zapcore.PanicLevel:     "ALERT",
+	zapcore.FatalLevel:     "EMERGENCY",
+}
+
+// gcpEncoderConfig returns the encoder configuration used by the "gcp" encoding.
+func gcpEncoderConfig(base zapcore.EncoderConfig) zapcore.EncoderConfig {
+	base.MessageKey = "message"
+	base.LevelKey = "severity"
+	base.TimeKey = "timestamp"
+	base.EncodeLevel = gcpSeverityEncoder
+	base.EncodeTime = zapcore.RFC3339NanoTimeEncoder
+	return base
+}
+
+// gcpSeverityEncoder writes the Cloud Logging severity for l, or DEFAULT for unknown levels.
+func gcpSeverityEncoder(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	if s, ok := gcpSeverities[l]; ok {
+		enc.AppendString(s)
+		return
+	}
+	enc.AppendString("DEFAULT")
+}
+
+// gcpTraceCore wraps a zapcore.Core and writes the "trace_id" field as the Cloud Logging trace
+// field, in the "projects/<id>/traces/<trace>" form when a project is configured. Like truncateCore
+// it also rewrites the context fields passed to With, which is where WithTraceID puts the id.
+type gcpTraceCore struct {
+	zapcore.Core
+	project string
+}
+
+// With rewrites the trace context field before handing it to the wrapped core.
+func (c *gcpTraceCore) With(fields []zapcore.Field) zapcore.Core {
+	return &gcpTraceCore{Core: c.Core.With(c.rewrite(fields)), project: c.project}
+}
+
+// Check registers the rewriting core for entries the wrapped core would accept.
+func (c *gcpTraceCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return checkWrapped(c.Core, c, ent, ce)
+}
+
+// Write rewrites the trace field of the entry and passes it to the wrapped core.
+func (c *gcpTraceCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(ent, c.rewrite(fields))
+}
+
+// rewrite returns fields with string "trace_id" fields replaced by the Cloud Logging trace field.
+// The caller's slice is copied only when it holds a trace id.
+func (c *gcpTraceCore) rewrite(fields []zapcore.Field) []zapcore.Field {
+	var out []zapcore.Field
+	for i, f := range fields {
+		if f.Key != traceIDKey || f.Type != zapcore.StringType {
+			continue
+		}
+		if out == nil {
+			out = append([]zapcore.Field(nil), fields...)
+		}
+		trace := f.String
+		if c.project != "" {
+			trace = "projects/" + c.project + "/traces/" + trace
+		}
+		out[i] = zap.String(gcpTraceKey, trace)
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
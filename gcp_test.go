@@ -0,0 +1,32 @@
+package log
+
+import (
+	"context"
+	"testing"
+)
+
+// Test the gcp encoding to verify Cloud Logging severities and the trace field taken from the context
+func TestEncodingGCP(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{Encoding: EncodingGCP, GCPProject: "shop"}, InfoLevel)
+	ctx := ContextWithTraceID(ToContext(context.Background(), logger), "4bf92f3577b34da6a3ce929d0e0e4736")
+
+	FromContext(ctx).Error("payment failed")
+	logger.Warn("no trace")
+
+	entries := decodeEntries(t, buf.String())
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	if entries[0]["severity"] != "ERROR" || entries[0]["message"] != "payment failed" {
+		t.Errorf("Expected an ERROR entry, got %v", entries[0])
+	}
+	if want := "projects/shop/traces/4bf92f3577b34da6a3ce929d0e0e4736"; entries[0][gcpTraceKey] != want {
+		t.Errorf("Expected trace %q, got %v", want, entries[0])
+	}
+	if _, ok := entries[0][traceIDKey]; ok {
+		t.Errorf("Expected trace_id to be replaced, got %v", entries[0])
+	}
+	if entries[1]["severity"] != "WARNING" || entries[1][gcpTraceKey] != nil {
+		t.Errorf("Expected a WARNING entry without trace, got %v", entries[1])
+	}
+}
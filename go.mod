@@ -2,6 +2,17 @@ module github.com/vadymlab/go-logger
 
 go 1.22
 
-require go.uber.org/zap v1.27.0
+require (
+	github.com/hashicorp/go-hclog v1.6.3 // Only imported by the hclogadapter package.
+	go.opentelemetry.io/otel v1.31.0
+	go.uber.org/multierr v1.10.0
+	go.uber.org/zap v1.27.0
+	golang.org/x/sys v0.25.0
+	google.golang.org/protobuf v1.34.2
+)
 
-require go.uber.org/multierr v1.10.0 // indirect
+require (
+	github.com/fatih/color v1.13.0 // indirect
+	github.com/mattn/go-colorable v0.1.12 // indirect
+	github.com/mattn/go-isatty v0.0.14 // indirect
+)
@@ -0,0 +1,219 @@
+// Package hclogadapter implements hclog.Logger on top of a log.Logger, for libraries such as
+// go-plugin that expect one. It lives in its own package so the core package does not import
+// go-hclog. The module requires go-hclog for this package only; with module graph pruning,
+// programs that do not import hclogadapter do not build or link it.
+package hclogadapter
+
+import (
+	"io"
+	stdlog "log"
+	"sync/atomic"
+
+	"github.com/hashicorp/go-hclog"
+
+	log "github.com/vadymlab/go-logger"
+)
+
+// hclogAdapter implements hclog.Logger on top of a log.Logger.
+type hclogAdapter struct {
+	root    log.Logger    // Logger without the name and implied arguments, used by ResetNamed.
+	log     log.Logger    // Logger with the name and implied arguments applied.
+	name    string        // Name as reported by Name, segments joined with ".".
+	implied []interface{} // Key-value pairs added through With.
+	off     *atomic.Bool  // Set by SetLevel(hclog.Off); shared like the level of the underlying logger.
+}
+
+// New returns an hclog.Logger writing through l. hclog levels map to the matching log.LogLevel and
+// key-value arguments are forwarded as fields.
+// hclog.Off drops every entry until another level is set.
+func New(l log.Logger) hclog.Logger {
+	// Report the caller of the hclog method rather than the adapter.
+	l = l.SkipCallers(2)
+	return &hclogAdapter{root: l, log: l, off: &atomic.Bool{}}
+}
+
+// toLogLevel converts an hclog level other than hclog.Off to a log.LogLevel; hclog.NoLevel means Info.
+func toLogLevel(level hclog.Level) log.LogLevel {
+	switch level {
+	case hclog.Trace:
+		return log.TraceLevel
+	case hclog.Debug:
+		return log.DebugLevel
+	case hclog.Warn:
+		return log.WarnLevel
+	case hclog.Error:
+		return log.ErrorLevel
+	default:
+		return log.InfoLevel
+	}
+}
+
+// toHclogLevel converts a log.LogLevel to the corresponding hclog level.
+func toHclogLevel(level log.LogLevel) hclog.Level {
+	switch level {
+	case log.TraceLevel:
+		return hclog.Trace
+	case log.DebugLevel:
+		return hclog.Debug
+	case log.InfoLevel:
+		return hclog.Info
+	case log.WarnLevel:
+		return hclog.Warn
+	default:
+		return hclog.Error
+	}
+}
+
+// enabled reports whether entries at level are written.
+func (a *hclogAdapter) enabled(level log.LogLevel) bool {
+	return !a.off.Load() && a.log.Check(level)
+}
+
+// write logs msg with args at level. Every exported method calls it directly, so the caller
+// skip set up by New stays correct.
+func (a *hclogAdapter) write(level hclog.Level, msg string, args []interface{}) {
+	if a.off.Load() {
+		return
+	}
+	switch level {
+	case hclog.Off:
+	case hclog.Trace:
+		if a.log.Check(log.TraceLevel) {
+			a.log.With(args...).Print(msg)
+		}
+	case hclog.Debug:
+		a.log.Debugw(msg, args...)
+	case hclog.Warn:
+		a.log.Warnw(msg, args...)
+	case hclog.Error:
+		a.log.Errorw(msg, args...)
+	default:
+		a.log.Infow(msg, args...)
+	}
+}
+
+// Log writes msg at level with the given key-value pairs.
+func (a *hclogAdapter) Log(level hclog.Level, msg string, args ...interface{}) {
+	a.write(level, msg, args)
+}
+
+// Trace writes msg at the trace level with the given key-value pairs when trace is enabled.
+func (a *hclogAdapter) Trace(msg string, args ...interface{}) {
+	a.write(hclog.Trace, msg, args)
+}
+
+// Debug writes msg at Debug with the given key-value pairs.
+func (a *hclogAdapter) Debug(msg string, args ...interface{}) {
+	a.write(hclog.Debug, msg, args)
+}
+
+// Info writes msg at Info with the given key-value pairs.
+func (a *hclogAdapter) Info(msg string, args ...interface{}) {
+	a.write(hclog.Info, msg, args)
+}
+
+// Warn writes msg at Warn with the given key-value pairs.
+func (a *hclogAdapter) Warn(msg string, args ...interface{}) {
+	a.write(hclog.Warn, msg, args)
+}
+
+// Error writes msg at Error with the given key-value pairs.
+func (a *hclogAdapter) Error(msg string, args ...interface{}) {
+	a.write(hclog.Error, msg, args)
+}
+
+// IsTrace reports whether trace entries are enabled.
+func (a *hclogAdapter) IsTrace() bool { return a.enabled(log.TraceLevel) }
+
+// IsDebug reports whether debug entries are enabled.
+func (a *hclogAdapter) IsDebug() bool { return a.enabled(log.DebugLevel) }
+
+// IsInfo reports whether info entries are enabled.
+func (a *hclogAdapter) IsInfo() bool { return a.enabled(log.InfoLevel) }
+
+// IsWarn reports whether warn entries are enabled.
+func (a *hclogAdapter) IsWarn() bool { return a.enabled(log.WarnLevel) }
+
+// IsError reports whether error entries are enabled.
+func (a *hclogAdapter) IsError() bool { return a.enabled(log.ErrorLevel) }
+
+// ImpliedArgs returns the key-value pairs added through With.
+func (a *hclogAdapter) ImpliedArgs() []interface{} {
+	return a.implied
+}
+
+// With returns an adapter adding args to every entry.
+func (a *hclogAdapter) With(args ...interface{}) hclog.Logger {
+	c := *a
+	c.log = a.log.With(args...)
+	c.implied = append(append([]interface{}(nil), a.implied...), args...)
+	return &c
+}
+
+// Name returns the name of the adapter.
+func (a *hclogAdapter) Name() string {
+	return a.name
+}
+
+// Named returns an adapter with name appended to the current name.
+func (a *hclogAdapter) Named(name string) hclog.Logger {
+	c := *a
+	c.log = a.log.Named(name)
+	c.name = name
+	if a.name != "" {
+		c.name = a.name + "." + name
+	}
+	return &c
+}
+
+// ResetNamed returns an adapter named name alone, keeping the implied arguments.
+func (a *hclogAdapter) ResetNamed(name string) hclog.Logger {
+	c := *a
+	c.log = a.root.With(a.implied...).Named(name)
+	c.name = name
+	return &c
+}
+
+// SetLevel changes the level of the underlying logger; hclog.Off drops every entry of the adapter
+// and the adapters derived from it instead. Errors, such as for an unconfigured logger, are ignored
+// because hclog has no way to report them.
+func (a *hclogAdapter) SetLevel(level hclog.Level) {
+	if level == hclog.Off {
+		a.off.Store(true)
+		return
+	}
+	a.off.Store(false)
+	_ = a.log.SetLevel(toLogLevel(level))
+}
+
+// GetLevel returns hclog.Off after SetLevel(hclog.Off), otherwise the most verbose level enabled
+// on the underlying logger.
+func (a *hclogAdapter) GetLevel() hclog.Level {
+	if a.off.Load() {
+		return hclog.Off
+	}
+	for level := log.TraceLevel; level > log.ErrorLevel; level-- {
+		if a.log.Check(level) {
+			return toHclogLevel(level)
+		}
+	}
+	return hclog.Error
+}
+
+// StandardLogger returns a standard library logger writing through the adapter.
+func (a *hclogAdapter) StandardLogger(opts *hclog.StandardLoggerOptions) *stdlog.Logger {
+	return stdlog.New(a.StandardWriter(opts), "", 0)
+}
+
+// StandardWriter returns a writer logging each line at opts.ForceLevel, or Info when unset, and
+// discarding lines when it is hclog.Off. Level inference from the line text is not supported.
+func (a *hclogAdapter) StandardWriter(opts *hclog.StandardLoggerOptions) io.Writer {
+	level := log.InfoLevel
+	if opts != nil && opts.ForceLevel == hclog.Off {
+		return io.Discard
+	}
+	if opts != nil && opts.ForceLevel != hclog.NoLevel {
+		level = toLogLevel(opts.ForceLevel)
+	}
+	return a.log.WriteCloser(level)
+}
@@ -0,0 +1,137 @@
+package hclogadapter
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+
+	log "github.com/vadymlab/go-logger"
+)
+
+// newFileLogger returns a JSON logger writing to a file and a function reading the entries written so far.
+func newFileLogger(t *testing.T, level string) (log.Logger, func() []map[string]interface{}) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger, err := log.NewLogger(&log.Config{IsJson: true, Level: level, OutputPaths: []string{path}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return logger, func() []map[string]interface{} {
+		t.Helper()
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var entries []map[string]interface{}
+		for _, line := range bytes.Split(bytes.TrimSpace(data), []byte("\n")) {
+			if len(line) == 0 {
+				continue
+			}
+			entry := map[string]interface{}{}
+			if err := json.Unmarshal(line, &entry); err != nil {
+				t.Fatalf("Failed to decode %q: %v", line, err)
+			}
+			entries = append(entries, entry)
+		}
+		return entries
+	}
+}
+
+// Test New to verify hclog Info with args is written like Infow from the caller's line
+func TestHclogAdapter_Info(t *testing.T) {
+	logger, read := newFileLogger(t, "info")
+	adapter := New(logger).Named("plugin").With("component", "rpc")
+
+	adapter.Info("started", "port", 8080)
+
+	entries := read()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %v", entries)
+	}
+	entry := entries[0]
+	if entry["message"] != "started" || entry["severity"] != "info" {
+		t.Errorf("Expected an info entry, got %v", entry)
+	}
+	if entry["port"] != float64(8080) || entry["component"] != "rpc" {
+		t.Errorf("Expected forwarded key-value pairs, got %v", entry)
+	}
+	if caller, _ := entry["module"].(string); !strings.Contains(caller, "adapter_test.go") {
+		t.Errorf("Expected the test file as caller, got %q", caller)
+	}
+	if adapter.Name() != "plugin" {
+		t.Errorf("Expected name %q, got %q", "plugin", adapter.Name())
+	}
+}
+
+// Test the hclog adapter to verify levels map to and from log.LogLevel
+func TestHclogAdapter_Levels(t *testing.T) {
+	logger, read := newFileLogger(t, "info")
+	adapter := New(logger)
+
+	adapter.Debug("hidden")
+	if entries := read(); len(entries) != 0 {
+		t.Fatalf("Expected debug to be filtered, got %v", entries)
+	}
+	if adapter.GetLevel() != hclog.Info || adapter.IsDebug() {
+		t.Errorf("Expected info level, got %v", adapter.GetLevel())
+	}
+
+	adapter.SetLevel(hclog.Debug)
+	adapter.Log(hclog.Debug, "shown")
+	if entries := read(); len(entries) != 1 || entries[0]["severity"] != "debug" {
+		t.Errorf("Expected a debug entry, got %v", entries)
+	}
+}
+
+// Test SetLevel with hclog.Off to verify every entry is dropped, including errors of derived adapters
+func TestHclogAdapter_Off(t *testing.T) {
+	logger, read := newFileLogger(t, "info")
+	adapter := New(logger)
+	named := adapter.Named("plugin")
+
+	adapter.SetLevel(hclog.Off)
+	adapter.Error("dropped")
+	named.Error("dropped too")
+	if entries := read(); len(entries) != 0 {
+		t.Fatalf("Expected no entries while off, got %v", entries)
+	}
+	if adapter.GetLevel() != hclog.Off || named.IsError() {
+		t.Errorf("Expected the off level, got %v", adapter.GetLevel())
+	}
+
+	adapter.SetLevel(hclog.Warn)
+	named.Error("shown")
+	if entries := read(); len(entries) != 1 || entries[0]["message"] != "shown" {
+		t.Errorf("Expected entries after leaving off, got %v", entries)
+	}
+}
+
+// Test the hclog adapter to verify Trace writes trace entries only when IsTrace reports them enabled
+func TestHclogAdapter_Trace(t *testing.T) {
+	logger, read := newFileLogger(t, "trace")
+	adapter := New(logger)
+	adapter.SetLevel(hclog.Debug)
+
+	adapter.Trace("hidden", "step", 1)
+	if entries := read(); len(entries) != 0 || adapter.IsTrace() {
+		t.Fatalf("Expected trace to be disabled at debug, got %v", entries)
+	}
+
+	adapter.SetLevel(hclog.Trace)
+	adapter.Trace("shown", "step", 2)
+	entries := read()
+	if len(entries) != 1 || !adapter.IsTrace() {
+		t.Fatalf("Expected 1 trace entry, got %v", entries)
+	}
+	if entry := entries[0]; entry["severity"] != "trace" || entry["step"] != float64(2) {
+		t.Errorf("Expected a trace entry with the arguments, got %v", entry)
+	}
+	if caller, _ := entries[0]["module"].(string); !strings.Contains(caller, "adapter_test.go") {
+		t.Errorf("Expected the test file as caller, got %q", caller)
+	}
+}
@@ -0,0 +1,46 @@
+package log
+
+import (
+	"go.uber.org/multierr"
+	"go.uber.org/zap/zapcore"
+)
+
+// RegisterHooks returns a logger like l that calls each hook with every entry it writes, after the
+// entry is written, as zapcore.RegisterHooks does. Loggers derived from the result call the hooks
+// as well; l itself and loggers derived from it before the call do not. Loggers not built by this
+// package are returned unchanged.
+func RegisterHooks(l Logger, hooks ...func(zapcore.Entry) error) Logger {
+	zl, ok := l.(*zapLogger)
+	if !ok {
+		return l
+	}
+	return zl.wrapCore(func(core zapcore.Core) zapcore.Core {
+		return &hookCore{Core: core, hooks: hooks}
+	})
+}
+
+// hookCore wraps a zapcore.Core and calls hooks after each entry it writes. Unlike the core of
+// zapcore.RegisterHooks, it also writes trace entries, which reach Write without a Check.
+type hookCore struct {
+	zapcore.Core
+	hooks []func(zapcore.Entry) error
+}
+
+// With preserves the hooks on cores derived with additional fields.
+func (c *hookCore) With(fields []zapcore.Field) zapcore.Core {
+	return &hookCore{Core: c.Core.With(fields), hooks: c.hooks}
+}
+
+// Check registers the hook core for entries the wrapped core would accept.
+func (c *hookCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return checkWrapped(c.Core, c, ent, ce)
+}
+
+// Write writes the entry to the wrapped core and then calls each hook.
+func (c *hookCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	err := c.Core.Write(ent, fields)
+	for _, hook := range c.hooks {
+		err = multierr.Append(err, hook(ent))
+	}
+	return err
+}
@@ -0,0 +1,46 @@
+package log
+
+import (
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Test RegisterHooks to verify hooks see the written entries of the logger and its derived loggers only
+func TestRegisterHooks(t *testing.T) {
+	base, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	var seen []string
+	hooked := RegisterHooks(base, func(ent zapcore.Entry) error {
+		seen = append(seen, ent.Message)
+		return nil
+	})
+
+	hooked.WithField("k", "v").Info("first")
+	hooked.Debug("disabled")
+	base.Info("unhooked")
+
+	if len(seen) != 1 || seen[0] != "first" {
+		t.Errorf("Expected the hook to see only the written hooked entry, got %q", seen)
+	}
+	if n := len(decodeEntries(t, buf.String())); n != 2 {
+		t.Errorf("Expected both entries to be written, got %d", n)
+	}
+}
+
+// Test RegisterHooks to verify Print entries, written without a Check, are written and hooked
+func TestRegisterHooks_Print(t *testing.T) {
+	base, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	var seen []string
+	hooked := RegisterHooks(base, func(ent zapcore.Entry) error {
+		seen = append(seen, ent.Message)
+		return nil
+	})
+
+	hooked.Print("printed")
+	if entry := decodeEntry(t, buf); entry["message"] != "printed" {
+		t.Errorf("Expected the printed entry, got %v", entry)
+	}
+	if len(seen) != 1 || seen[0] != "printed" {
+		t.Errorf("Expected the hook to see the printed entry, got %q", seen)
+	}
+}
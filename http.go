@@ -0,0 +1,92 @@
+package log
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// requestIDHeader is the header WithRequestFields reads the request id from.
+const requestIDHeader = "X-Request-Id"
+
+// LogHTTP writes a standardized access log entry for a completed HTTP request.
+// Server errors (5xx) are logged at Error, client errors (4xx) at Warn and everything else at Info.
+func LogHTTP(l Logger, r *http.Request, status int, bytes int, dur time.Duration) {
+	// Report the code calling LogHTTP rather than this helper as the caller.
+	l = l.SkipCallers(1)
+	fields := []interface{}{
+		"method", r.Method,
+		"path", r.URL.Path,
+		"status", status,
+		"bytes", bytes,
+		"duration", dur,
+		"remote_addr", r.RemoteAddr,
+		"user_agent", r.UserAgent(),
+	}
+
+	switch {
+	case status >= http.StatusInternalServerError:
+		l.Errorw("http request", fields...)
+	case status >= http.StatusBadRequest:
+		l.Warnw("http request", fields...)
+	default:
+		l.Infow("http request", fields...)
+	}
+}
+
+// LogOutbound writes a standardized entry for a completed outbound HTTP call. Failed calls (err set)
+// and server errors (5xx) are logged at Error, client errors (4xx) at Warn and everything else at
+// Info. status is 0 when no response was received.
+func LogOutbound(l Logger, method, url string, status int, dur time.Duration, err error) {
+	// Report the code calling LogOutbound rather than this helper as the caller.
+	l = l.SkipCallers(1)
+	fields := []interface{}{
+		"method", method,
+		"url", url,
+		"status", status,
+		"duration", dur,
+	}
+	if err != nil {
+		fields = append(fields, "error", err)
+	}
+
+	switch {
+	case err != nil || status >= http.StatusInternalServerError:
+		l.Errorw("http client request", fields...)
+	case status >= http.StatusBadRequest:
+		l.Warnw("http client request", fields...)
+	default:
+		l.Infow("http client request", fields...)
+	}
+}
+
+// WithRequestFields returns a logger carrying the standard fields of r: method, path, query, ip,
+// user_agent and, when the X-Request-Id header is set, request_id.
+func (l *zapLogger) WithRequestFields(r *http.Request) Logger {
+	fields := []interface{}{
+		"method", r.Method,
+		"path", r.URL.Path,
+		"query", r.URL.RawQuery,
+		"ip", clientIP(r),
+		"user_agent", r.UserAgent(),
+	}
+	if id := r.Header.Get(requestIDHeader); id != "" {
+		fields = append(fields, "request_id", id)
+	}
+	return l.withFields(fields...)
+}
+
+// clientIP returns the originating client address, preferring the first X-Forwarded-For hop
+// over the address of the connection.
+func clientIP(r *http.Request) string {
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+		first, _, _ := strings.Cut(fwd, ",")
+		return strings.TrimSpace(first)
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,115 @@
+package log
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Test LogHTTP to verify the level follows the status class and all standard fields are present
+func TestLogHTTP(t *testing.T) {
+	tests := []struct {
+		status int
+		want   string
+	}{
+		{200, "info"},
+		{404, "warn"},
+		{500, "error"},
+	}
+
+	for _, tt := range tests {
+		logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+		r := httptest.NewRequest("GET", "/users?id=1", nil)
+		r.Header.Set("User-Agent", "test-agent")
+
+		LogHTTP(logger, r, tt.status, 42, 150*time.Millisecond)
+
+		entry := decodeEntry(t, buf)
+		if entry["severity"] != tt.want {
+			t.Errorf("LogHTTP(status=%d) severity = %v; want %v", tt.status, entry["severity"], tt.want)
+		}
+		for _, key := range []string{"method", "path", "status", "bytes", "duration", "remote_addr", "user_agent"} {
+			if _, ok := entry[key]; !ok {
+				t.Errorf("LogHTTP(status=%d) missing field %q", tt.status, key)
+			}
+		}
+		if entry["path"] != "/users" || entry["user_agent"] != "test-agent" {
+			t.Errorf("LogHTTP(status=%d) unexpected request fields: %v", tt.status, entry)
+		}
+	}
+}
+
+// Test LogOutbound to verify the level follows the status class or error and the standard fields are present
+func TestLogOutbound(t *testing.T) {
+	tests := []struct {
+		status int
+		err    error
+		want   string
+	}{
+		{200, nil, "info"},
+		{404, nil, "warn"},
+		{500, nil, "error"},
+		{0, errors.New("connection refused"), "error"},
+	}
+
+	for _, tt := range tests {
+		logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+
+		LogOutbound(logger, "POST", "https://api.example.com/v1/charges", tt.status, 80*time.Millisecond, tt.err)
+
+		entry := decodeEntry(t, buf)
+		if entry["severity"] != tt.want {
+			t.Errorf("LogOutbound(status=%d) severity = %v; want %v", tt.status, entry["severity"], tt.want)
+		}
+		if entry["method"] != "POST" || entry["url"] != "https://api.example.com/v1/charges" || entry["status"] != float64(tt.status) {
+			t.Errorf("LogOutbound(status=%d) unexpected fields: %v", tt.status, entry)
+		}
+		if _, ok := entry["duration"]; !ok {
+			t.Errorf("LogOutbound(status=%d) missing field %q", tt.status, "duration")
+		}
+		if _, ok := entry["error"]; ok != (tt.err != nil) {
+			t.Errorf("LogOutbound(status=%d) error field = %v; want present %v", tt.status, entry["error"], tt.err != nil)
+		}
+		if module, _ := entry["module"].(string); !strings.Contains(module, "http_test.go") {
+			t.Errorf("LogOutbound(status=%d) caller = %q; want the test file", tt.status, module)
+		}
+	}
+}
+
+// Test WithRequestFields to verify the request fields are attached and X-Forwarded-For is honored
+func TestZapLogger_WithRequestFields(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	r := httptest.NewRequest("POST", "/orders?page=2", nil)
+	r.RemoteAddr = "10.0.0.1:5123"
+	r.Header.Set("User-Agent", "test-agent")
+	r.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.2")
+	r.Header.Set("X-Request-Id", "req-42")
+
+	logger.WithRequestFields(r).Info("handled")
+
+	entry := decodeEntry(t, buf)
+	expected := map[string]string{
+		"method":     "POST",
+		"path":       "/orders",
+		"query":      "page=2",
+		"ip":         "203.0.113.7",
+		"user_agent": "test-agent",
+		"request_id": "req-42",
+	}
+	for key, want := range expected {
+		if entry[key] != want {
+			t.Errorf("Expected %s %q, got %v", key, want, entry[key])
+		}
+	}
+}
+
+// Test clientIP to verify the connection address is used without X-Forwarded-For
+func TestClientIP(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "192.0.2.1:8080"
+	if ip := clientIP(r); ip != "192.0.2.1" {
+		t.Errorf("clientIP() = %q; want %q", ip, "192.0.2.1")
+	}
+}
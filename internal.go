@@ -0,0 +1,80 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+var (
+	internalErrorMu      sync.RWMutex
+	internalErrorHandler func(error) // Set by SetInternalErrorHandler; nil selects reportInternalErrorOnce.
+	internalErrorOnce    sync.Once
+)
+
+// SetInternalErrorHandler sets the function called when the logging subsystem itself fails, for
+// example when an output cannot be written because the disk is full or an encoder panics. By default
+// the first such error is reported on stderr and later ones are dropped. Passing nil restores the default.
+func SetInternalErrorHandler(fn func(error)) {
+	internalErrorMu.Lock()
+	defer internalErrorMu.Unlock()
+	internalErrorHandler = fn
+}
+
+// reportInternalError passes err to the handler set with SetInternalErrorHandler.
+func reportInternalError(err error) {
+	internalErrorMu.RLock()
+	fn := internalErrorHandler
+	internalErrorMu.RUnlock()
+	if fn == nil {
+		fn = reportInternalErrorOnce
+	}
+	fn(err)
+}
+
+// reportInternalErrorOnce writes the first internal error of the process to stderr.
+func reportInternalErrorOnce(err error) {
+	internalErrorOnce.Do(func() {
+		fmt.Fprintf(os.Stderr, "logger: %v; further internal errors are not reported\n", err)
+	})
+}
+
+// internalErrorCore wraps a zapcore.Core and reports write and sync failures of the wrapped core,
+// including encoder panics, through reportInternalError instead of letting them pass unnoticed.
+type internalErrorCore struct {
+	zapcore.Core
+}
+
+// With preserves the error reporting on cores derived with additional fields.
+func (c *internalErrorCore) With(fields []zapcore.Field) zapcore.Core {
+	return &internalErrorCore{c.Core.With(fields)}
+}
+
+// Check registers the reporting core for entries the wrapped core would accept.
+func (c *internalErrorCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return checkWrapped(c.Core, c, ent, ce)
+}
+
+// Write writes the entry and reports any error or panic of the wrapped core.
+func (c *internalErrorCore) Write(ent zapcore.Entry, fields []zapcore.Field) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic while writing entry: %v", r)
+		}
+		if err != nil {
+			reportInternalError(err)
+		}
+	}()
+	return c.Core.Write(ent, fields)
+}
+
+// Sync flushes the wrapped core and reports a failure.
+func (c *internalErrorCore) Sync() error {
+	err := c.Core.Sync()
+	if err != nil {
+		reportInternalError(err)
+	}
+	return err
+}
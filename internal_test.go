@@ -0,0 +1,69 @@
+package log
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// failingWriter is a WriteSyncer whose writes always fail
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("no space left on device") }
+func (failingWriter) Sync() error               { return nil }
+
+// panickingObject is an ObjectMarshaler that panics while being encoded
+type panickingObject struct{}
+
+func (panickingObject) MarshalLogObject(zapcore.ObjectEncoder) error { panic("broken marshaler") }
+
+// recordInternalErrors installs a handler collecting internal errors for the duration of the test
+func recordInternalErrors(t *testing.T) func() []error {
+	var mu sync.Mutex
+	var errs []error
+	SetInternalErrorHandler(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	})
+	t.Cleanup(func() { SetInternalErrorHandler(nil) })
+	return func() []error {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]error(nil), errs...)
+	}
+}
+
+// Test SetInternalErrorHandler to verify sink failures reach the handler
+func TestInternalErrorHandler_WriteError(t *testing.T) {
+	errs := recordInternalErrors(t)
+	logger, err := buildZap(&Config{IsJson: true}, InfoLevel, failingWriter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger.Info("lost")
+
+	if got := errs(); len(got) != 1 || !strings.Contains(got[0].Error(), "no space left") {
+		t.Errorf("Expected the write error to be reported, got %v", got)
+	}
+}
+
+// Test SetInternalErrorHandler to verify encoder panics are reported instead of propagating
+func TestInternalErrorHandler_EncodePanic(t *testing.T) {
+	errs := recordInternalErrors(t)
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+
+	logger.Infow("encode", zap.Object("obj", panickingObject{}))
+
+	if got := errs(); len(got) != 1 || !strings.Contains(got[0].Error(), "broken marshaler") {
+		t.Errorf("Expected the encoder panic to be reported, got %v", got)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected nothing to be written, got %q", buf.String())
+	}
+}
@@ -0,0 +1,80 @@
+package log
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// levelState holds a logger's atomic level together with a lookup table of enabled LogLevels,
+// so Check is an array read instead of a trip through the zap core.
+type levelState struct {
+	atom    zap.AtomicLevel
+	enabled [TraceLevel + 1]atomic.Bool
+	parent  *levelState // Levels of the logger this one was derived from with AtLevel; nil for a root.
+}
+
+// newLevelState creates a levelState with level as the minimum enabled severity.
+func newLevelState(level LogLevel) (*levelState, error) {
+	s := &levelState{atom: zap.NewAtomicLevel()}
+	if err := s.set(level); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// resolveLevel returns level, or InfoLevel for UnsetLevel.
+func resolveLevel(level LogLevel) LogLevel {
+	if level == UnsetLevel {
+		return InfoLevel
+	}
+	return level
+}
+
+// set changes the minimum enabled severity and refreshes the lookup table.
+func (s *levelState) set(level LogLevel) error {
+	level = resolveLevel(level)
+	lvl := convLevel(level)
+	if lvl == nil {
+		return errors.New("wrong logging level")
+	}
+	s.atom.SetLevel(*lvl)
+	for l := range s.enabled {
+		s.enabled[l].Store(LogLevel(l) <= level)
+	}
+	return nil
+}
+
+// check reports whether level is enabled, both by s and by the levels it was derived from.
+func (s *levelState) check(level LogLevel) bool {
+	level = resolveLevel(level)
+	if level > TraceLevel {
+		return false
+	}
+	return s.enabled[level].Load() && (s.parent == nil || s.parent.check(level))
+}
+
+// Enabled reports whether entries at the zap level lvl are enabled, making levelState a
+// zapcore.LevelEnabler that also recognizes trace entries.
+func (s *levelState) Enabled(lvl zapcore.Level) bool {
+	return s.check(fromZapLevel(lvl))
+}
+
+// severityNumbers maps zap levels to the numeric LogSeverity values used by Google Cloud Logging.
+var severityNumbers = map[zapcore.Level]int{
+	zapcore.DebugLevel - 1: 100,
+	zapcore.DebugLevel:     100,
+	zapcore.InfoLevel:      200,
+	zapcore.WarnLevel:      400,
+	zapcore.ErrorLevel:     500,
+	zapcore.DPanicLevel:    600,
+	zapcore.PanicLevel:     700,
+	zapcore.FatalLevel:     800,
+}
+
+// addSeverityNumber attaches the numeric severity of the entry as a "severityNumber" field.
+func addSeverityNumber(ent zapcore.Entry, fields []zapcore.Field) (zapcore.Entry, []zapcore.Field) {
+	return ent, appendFields(fields, zap.Int("severityNumber", severityNumbers[ent.Level]))
+}
@@ -0,0 +1,98 @@
+package log
+
+import (
+	"testing"
+)
+
+// Test LevelNumber to verify entries carry both the textual and the numeric severity
+func TestLevelNumber(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true, LevelNumber: true}, InfoLevel)
+	logger.Error("failed")
+
+	entry := decodeEntry(t, buf)
+	if entry["severity"] != "error" {
+		t.Errorf("Expected severity %q, got %v", "error", entry["severity"])
+	}
+	if entry["severityNumber"] != float64(500) {
+		t.Errorf("Expected severityNumber 500, got %v", entry["severityNumber"])
+	}
+}
+
+// Test UnsetLevel to verify the zero LogLevel behaves like InfoLevel rather than PanicLevel
+func TestUnsetLevel(t *testing.T) {
+	var zero LogLevel
+	if zero != UnsetLevel {
+		t.Fatalf("Expected the zero LogLevel to be UnsetLevel, got %v", zero)
+	}
+	if lvl := convLevel(zero); lvl == nil || *lvl != *convLevel(InfoLevel) {
+		t.Errorf("Expected the zero LogLevel to convert like InfoLevel, got %v", lvl)
+	}
+
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, DebugLevel)
+	if err := logger.SetLevel(zero); err != nil {
+		t.Fatal(err)
+	}
+	if !logger.Check(zero) || !logger.Check(InfoLevel) || logger.Check(DebugLevel) {
+		t.Error("Expected SetLevel(UnsetLevel) to enable Info and above only")
+	}
+
+	logger.Info("kept")
+	if entry := decodeEntry(t, buf); entry["message"] != "kept" {
+		t.Errorf("Expected the Info entry to be written, got %v", entry)
+	}
+}
+
+// Test UnsetLevel to verify zero-valued level fields in Config keep their defaults
+func TestUnsetLevel_ConfigDefaults(t *testing.T) {
+	// A zero ExitOnLevel must not make entries fatal, and a zero LevelAtMost compiles as Info.
+	logger, buf := newBufferedZap(t, &Config{IsJson: true, SampleRules: []SampleRule{{MessagePattern: "^noisy$"}}}, InfoLevel)
+	logger.Error("failed")
+	if entry := decodeEntry(t, buf); entry["message"] != "failed" {
+		t.Errorf("Expected the Error entry to be written, got %v", entry)
+	}
+}
+
+// Test AtLevel to verify the child keeps the parent's fields and name but filters by its own level
+func TestZapLogger_AtLevel(t *testing.T) {
+	parent, buf := newBufferedZap(t, &Config{IsJson: true}, DebugLevel)
+	base := parent.Named("api").WithField("request_id", "r-1")
+	child := base.AtLevel(WarnLevel)
+
+	child.Info("hidden")
+	base.Debug("parent debug")
+	child.Warn("shown")
+
+	entries := decodeEntries(t, buf.String())
+	if len(entries) != 2 || entries[0]["message"] != "parent debug" {
+		t.Fatalf("Expected the child to drop Info while the parent keeps Debug, got %s", buf.String())
+	}
+	if entries[1]["request_id"] != "r-1" || entries[1]["logger"] != "api" {
+		t.Errorf("Expected the child to keep the parent's fields and name, got %v", entries[1])
+	}
+	if child.Check(InfoLevel) || !base.Check(DebugLevel) {
+		t.Error("Expected Check to report the child's own level without changing the parent's")
+	}
+}
+
+// Test AtLevel with a lower level than the parent to verify Check and Trace keep following the parent's level
+func TestZapLogger_AtLevelBelowParent(t *testing.T) {
+	parent, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	child := parent.AtLevel(TraceLevel)
+
+	child.(*zapLogger).Trace("hidden trace")
+	child.Debug("hidden debug")
+	if child.Check(TraceLevel) || child.Check(DebugLevel) {
+		t.Error("Expected Check to report levels the parent has disabled as disabled")
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("Expected no output below the parent's level, got %s", buf.String())
+	}
+
+	if err := parent.SetLevel(TraceLevel); err != nil {
+		t.Fatal(err)
+	}
+	child.(*zapLogger).Trace("shown")
+	if entry := decodeEntry(t, buf); entry["message"] != "shown" {
+		t.Errorf("Expected the trace entry after lowering the parent's level, got %v", entry)
+	}
+}
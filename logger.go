@@ -2,11 +2,19 @@ package log
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"strings"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
 )
 
 const (
-	loggerKey = "logger"
+	loggerKey  = "logger"
+	traceIDKey = "trace_id"
 )
 
 // Logger is an interface that defines logging methods with various log levels and formats.
@@ -17,18 +25,30 @@ type Logger interface {
 	Infof(string, ...interface{})
 	// Infow writes an informational message with key-value pairs for context.
 	Infow(string, ...interface{})
+	// Infom writes a formatted informational message and returns the message.
+	Infom(string, ...interface{}) string
+	// InfoMap writes an informational message with the entries of a map as fields.
+	InfoMap(string, map[string]interface{})
 	// Warn writes a warning message.
 	Warn(...interface{})
 	// Warnf writes a formatted warning message.
 	Warnf(string, ...interface{})
 	// Warnw writes a warning message with key-value pairs for context.
 	Warnw(string, ...interface{})
+	// WarnMap writes a warning message with the entries of a map as fields.
+	WarnMap(string, map[string]interface{})
+	// WarnOnce writes a warning message only the first time the key is seen in the process.
+	WarnOnce(key, msg string)
 	// Error writes an error message.
 	Error(...interface{})
 	// Errorf writes a formatted error message.
 	Errorf(string, ...interface{})
 	// Errorw writes an error message with key-value pairs for context.
 	Errorw(string, ...interface{})
+	// Errorm writes a formatted error message and returns the message.
+	Errorm(string, ...interface{}) string
+	// ErrorMap writes an error message with the entries of a map as fields.
+	ErrorMap(string, map[string]interface{})
 	// Debug writes a debug message.
 	Debug(...interface{})
 	// Debugf writes a formatted debug message.
@@ -39,31 +59,105 @@ type Logger interface {
 	Fatal(...interface{})
 	// Fatalf writes a formatted fatal message.
 	Fatalf(string, ...interface{})
+	// DPanic writes an invariant violation message; it panics in development and logs an error otherwise.
+	DPanic(...interface{})
+	// DPanicf writes a formatted invariant violation message.
+	DPanicf(string, ...interface{})
 	// With adds fields for structured logging to all subsequent logs.
 	With(f ...interface{}) Logger
+	// WithValidated adds key-value pairs like With, returning an error for an odd count or a non-string key.
+	WithValidated(kvs ...interface{}) (Logger, error)
 	// Check returns true if the log level is enabled for the logger instance.
 	Check(level LogLevel) bool
+	// SetLevel changes the minimum log level of the logger and the loggers derived from it.
+	SetLevel(level LogLevel) error
+	// AtLevel returns a Logger with the same fields and output filtered by its own level.
+	AtLevel(level LogLevel) Logger
 	// Print logs a general message without a specific severity.
 	Print(v ...interface{})
+	// Named adds a name segment to the logger, nesting under any existing name.
+	Named(name string) Logger
 	// WithField adds a single key-value pair to the Logger instance.
 	WithField(key string, value interface{}) Logger
+	// WithSet adds all fields of a FieldSet to the Logger instance.
+	WithSet(set FieldSet) Logger
+	// Reset returns a Logger with the same output, name and level but no accumulated context fields.
+	Reset() Logger
+	// WithFieldIf adds a single key-value pair only when cond is true.
+	WithFieldIf(cond bool, key string, value interface{}) Logger
+	// WithTraceID attaches a correlation id as the "trace_id" field.
+	WithTraceID(id string) Logger
 	// WithError attaches an error to the Logger instance for context.
 	WithError(err error) Logger
+	// WithErrorChain attaches an error with its type and the messages of the errors it wraps.
+	WithErrorChain(err error) Logger
+	// WithCodedError attaches an error together with its code when it implements Code() string.
+	WithCodedError(err error) Logger
+	// WithSortedMap attaches a map as a nested object with its keys in sorted order.
+	WithSortedMap(key string, m interface{}) Logger
+	// WithMetric attaches a metric, published as CloudWatch Embedded Metric Format with the "emf" encoding.
+	WithMetric(name string, value float64, unit string) Logger
+	// WithObject attaches a value implementing zapcore.ObjectMarshaler as a nested object.
+	WithObject(key string, obj zapcore.ObjectMarshaler) Logger
 	// SkipCallers skips a specified number of call stack frames for cleaner logs.
 	SkipCallers(count int) Logger
+	// CallerAt skips additional call stack frames for the next log entry only.
+	CallerAt(skip int) Logger
+	// WithPrefix prepends a prefix to the message text of all subsequent logs.
+	WithPrefix(prefix string) Logger
+	// WithTimer adds an "elapsed_ms" field measuring the time since the logger was created.
+	WithTimer() Logger
+	// WithSequence adds a "seq" field counting the entries written through the logger, starting at 1.
+	WithSequence() Logger
+	// WithDynamic adds a field whose value is computed by a function each time an entry is written.
+	WithDynamic(key string, fn func() interface{}) Logger
+	// WithCounter increments the named in-process counter reported by Counters for every entry written.
+	WithCounter(name string) Logger
+	// WithStruct flattens the exported fields of a struct into logger context under a prefix.
+	WithStruct(prefix string, v interface{}) Logger
+	// WithContext attaches the configured context values of ctx to the Logger instance.
+	WithContext(ctx context.Context) Logger
+	// DebugContext writes a debug message with key-value pairs and the context fields of ctx.
+	DebugContext(ctx context.Context, msg string, kvs ...interface{})
+	// InfoContext writes an informational message with key-value pairs and the context fields of ctx.
+	InfoContext(ctx context.Context, msg string, kvs ...interface{})
+	// WarnContext writes a warning message with key-value pairs and the context fields of ctx.
+	WarnContext(ctx context.Context, msg string, kvs ...interface{})
+	// ErrorContext writes an error message with key-value pairs and the context fields of ctx.
+	ErrorContext(ctx context.Context, msg string, kvs ...interface{})
+	// WithRequestFields attaches the standard fields of an HTTP request to the Logger instance.
+	WithRequestFields(r *http.Request) Logger
+	// LogStackIf logs msg at level with the current goroutine stack in a "stack" field when cond is true.
+	LogStackIf(cond bool, level LogLevel, msg string)
+	// WriteCloser returns a writer that logs each written line at the given level.
+	WriteCloser(level LogLevel) io.WriteCloser
+	// IsConfigured returns false if the logger is an unconfigured fallback instance.
+	IsConfigured() bool
 }
 
 // LogLevel defines the severity of logs, from Panic (highest) to Trace (lowest).
 type LogLevel uint8
 
 var (
-	def            Logger          = nil // Global default logger instance
-	defaultContext context.Context = nil // Default context with logger settings
+	def             Logger          = nil // Global default logger instance
+	defaultContext  context.Context = nil // Default context with logger settings
+	contextFallback Logger          = nil // Logger used by FromContext when the context carries none
+)
+
+var (
+	builtDefaultMu sync.Mutex
+	builtDefault   Logger // Logger built from LoggerConfig by GetDefaultLogger, reused until SetDefaultLogger.
 )
 
 const (
+	// UnsetLevel is the zero value of LogLevel. It stands for a level that was not chosen and is
+	// treated as InfoLevel wherever a level is applied, so uninitialized fields are never Panic.
+	//
+	// Migration: UnsetLevel shifts the other levels up by one. Code using the named constants is
+	// unaffected; code storing levels as raw numbers must be updated.
+	UnsetLevel LogLevel = iota
 	// PanicLevel is the highest severity; logs and then panics.
-	PanicLevel LogLevel = iota
+	PanicLevel
 	// FatalLevel logs and then exits the application.
 	FatalLevel
 	// ErrorLevel is for errors that require attention.
@@ -79,13 +173,41 @@ const (
 )
 
 // NewLogger creates a new Logger instance based on the provided configuration.
+// It fails when Config.Level names an unknown level; an empty Level means InfoLevel.
 func NewLogger(conf *Config) (Logger, error) {
-	return newZap(conf.IsJson, Text2Level(conf.Level))
+	level, err := configLevel(conf)
+	if err != nil {
+		return nil, err
+	}
+	return newZapFromConfig(conf, level)
+}
+
+// NewWriterLogger creates a Logger like NewLogger that writes to w instead of Config.OutputPaths.
+// It exists for helpers such as logtest that need a logger on an arbitrary writer. A Config with
+// Sinks is rejected, and AsyncBufferSize, FlushIntervalMs and EventLogSource are ignored. Writes
+// to w are serialized.
+func NewWriterLogger(conf *Config, w io.Writer) (Logger, error) {
+	if len(conf.Sinks) > 0 {
+		return nil, errors.New("NewWriterLogger: Config.Sinks cannot be combined with a writer")
+	}
+	level, err := configLevel(conf)
+	if err != nil {
+		return nil, err
+	}
+	l, err := buildZap(conf, level, zapcore.AddSync(w))
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
 }
 
-// SetDefaultLogger sets a global Logger instance.
+// SetDefaultLogger sets a global Logger instance. It also discards the logger GetDefaultLogger built
+// from LoggerConfig, so after SetDefaultLogger(nil) the next call builds one from the current LoggerConfig.
 func SetDefaultLogger(l Logger) {
 	def = l
+	builtDefaultMu.Lock()
+	builtDefault = nil
+	builtDefaultMu.Unlock()
 }
 
 // SetDefaultContext sets a default context that may include logging configurations.
@@ -93,52 +215,150 @@ func SetDefaultContext(ctx context.Context) {
 	defaultContext = ctx
 }
 
-// GetDefaultLogger returns the global Logger instance or initializes it based on LoggerConfig.
+// SetContextFallback sets the Logger returned by FromContext when the context carries none,
+// taking precedence over the default context and default logger. Passing nil removes it.
+func SetContextFallback(l Logger) {
+	contextFallback = l
+}
+
+// SaveState captures the global default logger, default context, context fallback and LoggerConfig,
+// returning a function that restores them. It is meant for tests: defer SaveState()() or t.Cleanup(SaveState()).
+func SaveState() func() {
+	savedLogger, savedContext, savedFallback, savedConfig := def, defaultContext, contextFallback, LoggerConfig
+	builtDefaultMu.Lock()
+	savedBuilt := builtDefault
+	builtDefaultMu.Unlock()
+	return func() {
+		def, defaultContext, contextFallback, LoggerConfig = savedLogger, savedContext, savedFallback, savedConfig
+		builtDefaultMu.Lock()
+		builtDefault = savedBuilt
+		builtDefaultMu.Unlock()
+	}
+}
+
+// GetDefaultLogger returns the global Logger instance or, when none is set, a logger built from
+// LoggerConfig. That logger is built once and reused, so its outputs are opened only once; later
+// changes to LoggerConfig apply after SetDefaultLogger(nil).
 func GetDefaultLogger() Logger {
 	if def != nil {
 		return def
 	}
-	if LoggerConfig.Level == "" {
-		LoggerConfig.Level = "DEBUG"
+	builtDefaultMu.Lock()
+	defer builtDefaultMu.Unlock()
+	if builtDefault != nil {
+		return builtDefault
+	}
+	// Work on a copy so concurrent callers do not race on LoggerConfig.
+	conf := LoggerConfig
+	if conf.Level == "" {
+		conf.Level = "DEBUG"
 	}
-	l, err := newZap(LoggerConfig.IsJson, Text2Level(LoggerConfig.Level))
+	l, err := newZapFromConfig(&conf, Text2Level(conf.Level))
 	if err != nil {
 		panic(err) // Panic if logger initialization fails
 	}
+	builtDefault = l
 	return l
 }
 
+// TeeDefault copies everything written by the default logger to w, in addition to its usual output.
+// The returned function stops the copy and restores the previous default logger. Loggers that were not
+// built by this package and loggers built with Config.Sinks, which have no single output, cannot be
+// tee'd: an internal error is reported and the returned function does nothing.
+func TeeDefault(w io.Writer) func() {
+	prev := def
+	l, ok := GetDefaultLogger().(*zapLogger)
+	if !ok || l.sink == nil {
+		reportInternalError(errors.New("TeeDefault: the default logger has no single output to copy"))
+		return func() {}
+	}
+
+	def = l
+	remove := l.sink.addMirror(zapcore.AddSync(w))
+	return func() {
+		remove()
+		def = prev
+	}
+}
+
+// SetDefaultOutput redirects the default logger to w, keeping its level and the fields of loggers
+// already derived from it. File outputs it replaces are closed, so Reopen leaves them closed.
+// Loggers that were not built by this package and loggers built with Config.Sinks are left
+// untouched, and an internal error is reported.
+func SetDefaultOutput(w io.Writer) {
+	l, ok := GetDefaultLogger().(*zapLogger)
+	if !ok || l.sink == nil {
+		reportInternalError(errors.New("SetDefaultOutput: the default logger has no single output to redirect"))
+		return
+	}
+
+	def = l
+	l.sink.setOutput(zapcore.AddSync(w))
+	for _, f := range l.files {
+		_ = f.Close()
+	}
+	l.files = nil
+}
+
 // ToContext attaches a Logger to a given context for retrieval in other parts of the app.
 func ToContext(ctx context.Context, l Logger) context.Context {
 	return context.WithValue(ctx, loggerKey, l)
 }
 
-// FromContext retrieves a Logger from the provided context or falls back to a default logger.
+// NewContext builds a Logger from conf with NewLogger and returns it together with a copy of parent
+// carrying it, ready for FromContext.
+func NewContext(parent context.Context, conf *Config) (context.Context, Logger, error) {
+	l, err := NewLogger(conf)
+	if err != nil {
+		return parent, nil, err
+	}
+	return ToContext(parent, l), l, nil
+}
+
+// Mute returns a copy of ctx carrying a Logger that discards everything, so code logging through
+// FromContext stays quiet. Keep using ctx itself where logging should continue.
+func Mute(ctx context.Context) context.Context {
+	return ToContext(ctx, newZapNop())
+}
+
+// FromContext retrieves a Logger from the provided context or falls back to the logger set with
+// SetContextFallback, then to FromDefaultContext. The values of ctx under the configured ContextKeys
+// and the trace id stored in ctx are attached as fields, as WithContext does. Missing
+// RequiredContextKeys are reported in a Warn entry.
 func FromContext(ctx context.Context) Logger {
 	var l Logger
 	o := ctx.Value(loggerKey)
 	if o == nil {
-		l = FromDefaultContext()
+		l = contextFallback
+		if l == nil {
+			l = FromDefaultContext()
+		}
 	} else {
 		if loggerFromContext, ok := o.(Logger); ok {
-			return loggerFromContext
+			l = loggerFromContext
 		} else {
 			return nil
 		}
 	}
-	return l
+	if zl, ok := l.(*zapLogger); ok {
+		// Called directly so the warning about missing required keys reports the caller of FromContext.
+		return zl.withContext(ctx)
+	}
+	return l.WithContext(ctx)
 }
 
 // FromDefaultContext returns a Logger instance based on defaultContext settings.
 func FromDefaultContext() Logger {
 	var l Logger
-	// Initialize defaultContext if it is nil
-	if defaultContext == nil {
-		defaultContext = context.Background()
+	// Fall back to an empty context if defaultContext is nil, without
+	// writing the global so concurrent callers do not race.
+	ctx := defaultContext
+	if ctx == nil {
+		ctx = context.Background()
 	}
 
 	// Retrieve Logger from defaultContext or use GetDefaultLogger
-	if loggerFromContext, ok := defaultContext.Value(loggerKey).(Logger); ok {
+	if loggerFromContext, ok := ctx.Value(loggerKey).(Logger); ok {
 		l = loggerFromContext
 	} else {
 		l = GetDefaultLogger()
@@ -147,26 +367,39 @@ func FromDefaultContext() Logger {
 	return l
 }
 
-// Text2Level converts a string log level to a LogLevel enum for structured logging.
-func Text2Level(level string) LogLevel {
-	var logLevel LogLevel
-	switch strings.ToUpper(level) {
+// ParseLevel converts a level name such as "info" or "WARNING" to a LogLevel, ignoring case.
+// Unlike Text2Level, it returns an error for names it does not recognize.
+func ParseLevel(level string) (LogLevel, error) {
+	switch strings.ToUpper(strings.TrimSpace(level)) {
 	case "TRACE":
-		logLevel = TraceLevel
+		return TraceLevel, nil
 	case "DEBUG":
-		logLevel = DebugLevel
+		return DebugLevel, nil
 	case "INFO":
-		logLevel = InfoLevel
-	case "WARNING":
-		logLevel = WarnLevel
+		return InfoLevel, nil
+	case "WARNING", "WARN":
+		return WarnLevel, nil
 	case "ERROR":
-		logLevel = ErrorLevel
+		return ErrorLevel, nil
 	case "FATAL":
-		logLevel = FatalLevel
+		return FatalLevel, nil
 	case "PANIC":
-		logLevel = PanicLevel
-	case "UNKNOWN":
-		logLevel = InfoLevel
+		return PanicLevel, nil
 	}
+	return InfoLevel, fmt.Errorf("unknown log level %q", level)
+}
+
+// Text2Level converts a string log level to a LogLevel enum for structured logging.
+// Unrecognized names map to InfoLevel; use ParseLevel to detect them.
+func Text2Level(level string) LogLevel {
+	logLevel, _ := ParseLevel(level)
 	return logLevel
 }
+
+// configLevel returns the level named by Config.Level, defaulting to InfoLevel when it is empty.
+func configLevel(conf *Config) (LogLevel, error) {
+	if conf.Level == "" {
+		return InfoLevel, nil
+	}
+	return ParseLevel(conf.Level)
+}
@@ -1,8 +1,18 @@
 package log
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/protobuf/proto"
 )
 
 // Test Text2Level function to ensure string values are correctly converted to LogLevel
@@ -29,8 +39,26 @@ func TestText2Level(t *testing.T) {
 	}
 }
 
+// Test ParseLevel to verify known names parse case-insensitively and unknown names are rejected
+func TestParseLevel(t *testing.T) {
+	if level, err := ParseLevel("warn"); err != nil || level != WarnLevel {
+		t.Errorf("ParseLevel(warn) = %v, %v; want %v", level, err, WarnLevel)
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("Expected an error for an unknown level")
+	}
+}
+
+// Test NewLogger to verify an unknown configured level fails fast
+func TestNewLogger_UnknownLevel(t *testing.T) {
+	if _, err := NewLogger(&Config{Level: "verbose"}); err == nil {
+		t.Error("Expected an error for an unknown level")
+	}
+}
+
 // Test GetDefaultLogger function to check the initialization of the default logger
 func TestGetDefaultLogger(t *testing.T) {
+	t.Cleanup(SaveState())
 	SetDefaultLogger(nil) // Reset default logger for test
 	logger := GetDefaultLogger()
 	if logger == nil {
@@ -38,6 +66,26 @@ func TestGetDefaultLogger(t *testing.T) {
 	}
 }
 
+// Test GetDefaultLogger to verify the logger built from LoggerConfig is reused rather than rebuilt
+func TestGetDefaultLogger_BuiltOnce(t *testing.T) {
+	t.Cleanup(SaveState())
+	LoggerConfig = Config{IsJson: true, OutputPaths: []string{filepath.Join(t.TempDir(), "app.log")}}
+	SetDefaultLogger(nil)
+
+	first := GetDefaultLogger()
+	t.Cleanup(func() { _ = first.(*zapLogger).files[0].Close() })
+	if FromContext(context.Background()) != first || GetDefaultLogger() != first {
+		t.Error("Expected every call to return the same default logger")
+	}
+
+	SetDefaultLogger(nil)
+	second := GetDefaultLogger()
+	t.Cleanup(func() { _ = second.(*zapLogger).files[0].Close() })
+	if second == first {
+		t.Error("Expected SetDefaultLogger(nil) to rebuild the default logger")
+	}
+}
+
 // Test ToContext and FromContext to check adding and retrieving logger from context
 func TestToContextAndFromContext(t *testing.T) {
 	ctx := context.Background()
@@ -50,8 +98,101 @@ func TestToContextAndFromContext(t *testing.T) {
 	}
 }
 
+// Test SetContextFallback to verify FromContext prefers it over the default logger when the context has none
+func TestSetContextFallback(t *testing.T) {
+	t.Cleanup(SaveState())
+	fallback := &MockLogger{}
+	SetDefaultLogger(&MockLogger{})
+	SetContextFallback(fallback)
+
+	if l := FromContext(context.Background()); l != fallback {
+		t.Errorf("Expected the fallback logger, got %v", l)
+	}
+	inContext, _ := newBufferedZap(t, &Config{}, InfoLevel)
+	if l := FromContext(ToContext(context.Background(), inContext)); l != Logger(inContext) {
+		t.Errorf("Expected the context logger to take precedence, got %v", l)
+	}
+}
+
+// Test FromContext to verify values under the configured ContextKeys become fields
+func TestFromContext_ContextKeys(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true, ContextKeys: []string{"tenant", "user"}}, InfoLevel)
+	ctx := context.WithValue(context.Background(), "tenant", "acme")
+	ctx = context.WithValue(ctx, "user", 7)
+	ctx = context.WithValue(ctx, "other", "ignored")
+
+	FromContext(ToContext(ctx, logger)).Info("scoped")
+
+	entry := decodeEntry(t, buf)
+	if entry["tenant"] != "acme" || entry["user"] != float64(7) {
+		t.Errorf("Expected tenant and user fields, got %v", entry)
+	}
+	if _, ok := entry["other"]; ok {
+		t.Errorf("Expected unregistered key to be skipped, got %v", entry)
+	}
+}
+
+// Test InfoContext and ErrorContext to verify registered context keys become fields of the emitted line
+func TestZapLogger_InfoContext(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true, ContextKeys: []string{"tenant"}}, InfoLevel)
+	ctx := context.WithValue(context.Background(), "tenant", "acme")
+
+	logger.InfoContext(ctx, "served", "status", 200)
+	logger.ErrorContext(ctx, "failed")
+	logger.DebugContext(ctx, "hidden")
+
+	entries := decodeEntries(t, buf.String())
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	if e := entries[0]; e["tenant"] != "acme" || e["status"] != float64(200) || e["severity"] != "info" {
+		t.Errorf("Expected context and call fields, got %v", e)
+	}
+	if e := entries[1]; e["tenant"] != "acme" || e["severity"] != "error" {
+		t.Errorf("Expected an error entry with the context field, got %v", e)
+	}
+	if caller, _ := entries[0]["module"].(string); !strings.Contains(caller, "logger_test.go") {
+		t.Errorf("Expected the test file as caller, got %q", caller)
+	}
+}
+
+// Test NewContext to verify FromContext returns the logger it built and config errors are reported
+func TestNewContext(t *testing.T) {
+	ctx, logger, err := NewContext(context.Background(), &Config{IsJson: true, Level: "INFO"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := FromContext(ctx); got != logger {
+		t.Errorf("Expected the built logger from the context, got %v", got)
+	}
+
+	if _, _, err := NewContext(context.Background(), &Config{Level: "verbose"}); err == nil {
+		t.Error("Expected an error for an invalid config")
+	}
+}
+
+// Test Mute to verify loggers from the muted context write nothing while the original context still logs
+func TestMute(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, TraceLevel)
+	ctx := ToContext(context.Background(), logger)
+
+	muted := FromContext(Mute(ctx))
+	muted.Info("hidden")
+	muted.Print("hidden")
+	muted.Errorw("hidden", "key", "value")
+	if buf.Len() != 0 {
+		t.Fatalf("Expected no output from the muted context, got %q", buf.String())
+	}
+
+	FromContext(ctx).Info("shown")
+	if entry := decodeEntry(t, buf); entry["message"] != "shown" {
+		t.Errorf("Expected the original context to keep logging, got %v", entry)
+	}
+}
+
 // Test FromDefaultContext to check initialization and retrieval of logger from defaultContext
 func TestFromDefaultContext(t *testing.T) {
+	t.Cleanup(SaveState())
 	SetDefaultContext(nil) // Ensure defaultContext is initialized for the test
 	logger := FromDefaultContext()
 	if logger == nil {
@@ -59,26 +200,241 @@ func TestFromDefaultContext(t *testing.T) {
 	}
 }
 
+// Test TeeDefault to verify default logger output reaches both stdout and the tee'd writer
+func TestTeeDefault(t *testing.T) {
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	t.Cleanup(SaveState())
+	SetDefaultLogger(nil)
+	buf := &bytes.Buffer{}
+	restore := TeeDefault(buf)
+	GetDefaultLogger().Info("tee'd line")
+	restore()
+	w.Close()
+
+	captured, _ := io.ReadAll(r)
+	if !strings.Contains(string(captured), "tee'd line") {
+		t.Errorf("Expected line on stdout, got %q", captured)
+	}
+	if !strings.Contains(buf.String(), "tee'd line") {
+		t.Errorf("Expected line in tee'd writer, got %q", buf.String())
+	}
+	if def != nil {
+		t.Error("Expected restore to reset the default logger")
+	}
+}
+
+// Test SetDefaultOutput to verify the default logger and its derived loggers write to the new sink
+func TestSetDefaultOutput(t *testing.T) {
+	t.Cleanup(SaveState())
+	logger, err := NewLogger(&Config{IsJson: true, Level: "INFO"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetDefaultLogger(logger)
+	derived := GetDefaultLogger().WithField("key", "value")
+
+	buf := &bytes.Buffer{}
+	SetDefaultOutput(buf)
+	derived.Info("redirected")
+	derived.Debug("filtered")
+
+	out := buf.String()
+	if !strings.Contains(out, "redirected") || !strings.Contains(out, `"key":"value"`) {
+		t.Errorf("Expected redirected line with fields, got %q", out)
+	}
+	if strings.Contains(out, "filtered") {
+		t.Errorf("Expected level to be preserved, got %q", out)
+	}
+}
+
+// Test SetDefaultOutput to verify a replaced file output is closed and not recreated by Reopen
+func TestSetDefaultOutput_File(t *testing.T) {
+	t.Cleanup(SaveState())
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger, err := NewLogger(&Config{IsJson: true, OutputPaths: []string{path}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetDefaultLogger(logger)
+	derived := logger.WithField("key", "value")
+
+	SetDefaultOutput(io.Discard)
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	for _, l := range []Logger{logger, derived} {
+		if err := l.(Reopener).Reopen(); err != nil {
+			t.Errorf("Reopen returned error: %v", err)
+		}
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected the replaced file to stay closed, got %v", err)
+	}
+}
+
+// Test TeeDefault and SetDefaultOutput to verify a Sinks default logger is reported instead of silently ignored
+func TestSetDefaultOutput_Sinks(t *testing.T) {
+	t.Cleanup(SaveState())
+	errs := recordInternalErrors(t)
+	logger, err := NewLogger(&Config{Sinks: []Sink{{OutputPaths: []string{filepath.Join(t.TempDir(), "app.log")}}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetDefaultLogger(logger)
+
+	TeeDefault(io.Discard)()
+	SetDefaultOutput(io.Discard)
+	if got := errs(); len(got) != 2 {
+		t.Errorf("Expected both calls to be reported, got %v", got)
+	}
+}
+
+// Test SaveState to verify mutated globals are restored
+func TestSaveState(t *testing.T) {
+	restore := SaveState()
+	logger := &MockLogger{}
+	ctx := context.WithValue(context.Background(), loggerKey, logger)
+	prevLogger, prevContext, prevFallback, prevConfig := def, defaultContext, contextFallback, LoggerConfig
+
+	SetDefaultLogger(logger)
+	SetDefaultContext(ctx)
+	SetContextFallback(logger)
+	LoggerConfig.Level = "ERROR"
+	LoggerConfig.IsJson = !LoggerConfig.IsJson
+	restore()
+
+	if def != prevLogger || defaultContext != prevContext || contextFallback != prevFallback || !reflect.DeepEqual(LoggerConfig, prevConfig) {
+		t.Error("Expected SaveState restore function to bring back the original globals")
+	}
+}
+
 // MockLogger to simulate a logger in tests
 type MockLogger struct{}
 
-func (m *MockLogger) Info(args ...interface{})                        {}
-func (m *MockLogger) Infof(format string, args ...interface{})        {}
-func (m *MockLogger) Infow(msg string, keysAndValues ...interface{})  {}
-func (m *MockLogger) Warn(args ...interface{})                        {}
-func (m *MockLogger) Warnf(format string, args ...interface{})        {}
-func (m *MockLogger) Warnw(msg string, keysAndValues ...interface{})  {}
-func (m *MockLogger) Error(args ...interface{})                       {}
-func (m *MockLogger) Errorf(format string, args ...interface{})       {}
-func (m *MockLogger) Errorw(msg string, keysAndValues ...interface{}) {}
-func (m *MockLogger) Debug(args ...interface{})                       {}
-func (m *MockLogger) Debugf(format string, args ...interface{})       {}
-func (m *MockLogger) Debugw(msg string, keysAndValues ...interface{}) {}
-func (m *MockLogger) Fatal(args ...interface{})                       {}
-func (m *MockLogger) Fatalf(format string, args ...interface{})       {}
-func (m *MockLogger) With(f ...interface{}) Logger                    { return m }
-func (m *MockLogger) Print(v ...interface{})                          {}
-func (m *MockLogger) WithField(key string, value interface{}) Logger  { return m }
-func (m *MockLogger) WithError(err error) Logger                      { return m }
-func (m *MockLogger) SkipCallers(count int) Logger                    { return m }
-func (m *MockLogger) Check(level LogLevel) bool                       { return true }
+func (m *MockLogger) Info(args ...interface{})                                         {}
+func (m *MockLogger) Infof(format string, args ...interface{})                         {}
+func (m *MockLogger) Infow(msg string, keysAndValues ...interface{})                   {}
+func (m *MockLogger) Warn(args ...interface{})                                         {}
+func (m *MockLogger) Warnf(format string, args ...interface{})                         {}
+func (m *MockLogger) Warnw(msg string, keysAndValues ...interface{})                   {}
+func (m *MockLogger) WarnOnce(key, msg string)                                         {}
+func (m *MockLogger) Error(args ...interface{})                                        {}
+func (m *MockLogger) Errorf(format string, args ...interface{})                        {}
+func (m *MockLogger) Errorw(msg string, keysAndValues ...interface{})                  {}
+func (m *MockLogger) Debug(args ...interface{})                                        {}
+func (m *MockLogger) Debugf(format string, args ...interface{})                        {}
+func (m *MockLogger) Debugw(msg string, keysAndValues ...interface{})                  {}
+func (m *MockLogger) InfoMap(msg string, fields map[string]interface{})                {}
+func (m *MockLogger) WarnMap(msg string, fields map[string]interface{})                {}
+func (m *MockLogger) ErrorMap(msg string, fields map[string]interface{})               {}
+func (m *MockLogger) Fatal(args ...interface{})                                        {}
+func (m *MockLogger) Fatalf(format string, args ...interface{})                        {}
+func (m *MockLogger) With(f ...interface{}) Logger                                     { return m }
+func (m *MockLogger) WithValidated(kvs ...interface{}) (Logger, error)                 { return m, validatePairs(kvs) }
+func (m *MockLogger) Print(v ...interface{})                                           {}
+func (m *MockLogger) WithField(key string, value interface{}) Logger                   { return m }
+func (m *MockLogger) WithSet(set FieldSet) Logger                                      { return m }
+func (m *MockLogger) Reset() Logger                                                    { return m }
+func (m *MockLogger) WithFieldIf(cond bool, key string, value interface{}) Logger      { return m }
+func (m *MockLogger) WithError(err error) Logger                                       { return m }
+func (m *MockLogger) WithErrorChain(err error) Logger                                  { return m }
+func (m *MockLogger) WithCodedError(err error) Logger                                  { return m }
+func (m *MockLogger) SkipCallers(count int) Logger                                     { return m }
+func (m *MockLogger) Check(level LogLevel) bool                                        { return true }
+func (m *MockLogger) IsConfigured() bool                                               { return true }
+func (m *MockLogger) WithPrefix(prefix string) Logger                                  { return m }
+func (m *MockLogger) WriteCloser(level LogLevel) io.WriteCloser                        { return nopWriteCloser{io.Discard} }
+func (m *MockLogger) LogStackIf(cond bool, level LogLevel, msg string)                 {}
+func (m *MockLogger) SetLevel(level LogLevel) error                                    { return nil }
+func (m *MockLogger) AtLevel(level LogLevel) Logger                                    { return m }
+func (m *MockLogger) WithStruct(prefix string, v interface{}) Logger                   { return m }
+func (m *MockLogger) WithProto(key string, msg proto.Message, redact ...string) Logger { return m }
+func (m *MockLogger) DPanic(args ...interface{})                                       {}
+func (m *MockLogger) DPanicf(format string, args ...interface{})                       {}
+func (m *MockLogger) CallerAt(skip int) Logger                                         { return m }
+func (m *MockLogger) WithObject(key string, obj zapcore.ObjectMarshaler) Logger        { return m }
+func (m *MockLogger) WithSortedMap(key string, v interface{}) Logger                   { return m }
+func (m *MockLogger) Named(name string) Logger                                         { return m }
+func (m *MockLogger) WithSequence() Logger                                             { return m }
+func (m *MockLogger) WithTimer() Logger                                                { return m }
+func (m *MockLogger) WithDynamic(key string, fn func() interface{}) Logger             { return m }
+func (m *MockLogger) WithCounter(name string) Logger                                   { return m }
+func (m *MockLogger) WithMetric(name string, value float64, unit string) Logger        { return m }
+func (m *MockLogger) Infom(format string, args ...interface{}) string {
+	return formatMessage(format, args)
+}
+func (m *MockLogger) Errorm(format string, args ...interface{}) string {
+	return formatMessage(format, args)
+}
+func (m *MockLogger) WithTraceID(id string) Logger                                     { return m }
+func (m *MockLogger) WithContext(ctx context.Context) Logger                           { return m }
+func (m *MockLogger) DebugContext(ctx context.Context, msg string, kvs ...interface{}) {}
+func (m *MockLogger) InfoContext(ctx context.Context, msg string, kvs ...interface{})  {}
+func (m *MockLogger) WarnContext(ctx context.Context, msg string, kvs ...interface{})  {}
+func (m *MockLogger) ErrorContext(ctx context.Context, msg string, kvs ...interface{}) {}
+func (m *MockLogger) WithRequestFields(r *http.Request) Logger                         { return m }
+
+// nopWriteCloser adds a no-op Close to an io.Writer
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+// Test FromContext to verify required context keys are attached and a missing one is reported
+func TestFromContext_RequiredContextKeys(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true, RequiredContextKeys: []string{"tenant"}}, InfoLevel)
+	ctx := ToContext(context.Background(), logger)
+
+	FromContext(context.WithValue(ctx, "tenant", "acme")).Info("attributed")
+	entry := decodeEntry(t, buf)
+	if entry["tenant"] != "acme" || entry["message"] != "attributed" {
+		t.Errorf("Expected the tenant field without a warning, got %s", buf.String())
+	}
+
+	buf.Reset()
+	FromContext(ctx).Info("unattributed")
+	entries := decodeEntries(t, buf.String())
+	if len(entries) != 2 || entries[0]["severity"] != "warn" || !strings.Contains(entries[0][loggerErrorKey].(string), "tenant") {
+		t.Fatalf("Expected a warning naming the missing key, got %s", buf.String())
+	}
+	if !strings.Contains(entries[0]["module"].(string), "logger_test.go") {
+		t.Errorf("Expected the warning to report the test as caller, got %v", entries[0]["module"])
+	}
+}
+
+// Test the Context methods to verify a disabled level neither writes nor warns about missing required keys
+func TestZapLogger_DebugContextDisabled(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true, RequiredContextKeys: []string{"tenant"}}, InfoLevel)
+
+	logger.DebugContext(context.Background(), "hidden")
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output for a disabled level, got %s", buf.String())
+	}
+}
+
+// Test NewWriterLogger to verify entries at the configured level are written to the writer
+func TestNewWriterLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger, err := NewWriterLogger(&Config{IsJson: true, Level: "warn"}, buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("hidden")
+	logger.Warn("shown")
+
+	if entry := decodeEntry(t, buf); entry["message"] != "shown" || entry["severity"] != "warn" {
+		t.Errorf("Expected only the warn entry, got %v", entry)
+	}
+	if _, err := NewWriterLogger(&Config{Level: "loud"}, buf); err == nil {
+		t.Error("Expected an error for an unknown level")
+	}
+	if _, err := NewWriterLogger(&Config{Sinks: []Sink{{Level: "info"}}}, buf); err == nil {
+		t.Error("Expected an error for a Config with Sinks")
+	}
+}
@@ -0,0 +1,79 @@
+// Package logtest provides helpers for using the log package in tests. It is kept apart from the
+// log package so that programs using log do not import testing.
+package logtest
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+
+	log "github.com/vadymlab/go-logger"
+)
+
+// FailOnError makes the default logger and the context fallback report every entry at Error or
+// above as a failure of t, while still writing it. The returned function restores the previous
+// loggers; it is also registered with t.Cleanup. Only loggers obtained after the call through
+// GetDefaultLogger, or FromContext without a logger in the context, are covered: loggers derived
+// before the call and loggers stored with ToContext are not. If the default logger was not built
+// by the log package, nothing is reported.
+func FailOnError(t testing.TB) func() {
+	t.Helper()
+	restore := log.SaveState()
+	t.Cleanup(restore)
+
+	failing := log.RegisterHooks(log.GetDefaultLogger(), func(ent zapcore.Entry) error {
+		if ent.Level >= zapcore.ErrorLevel {
+			t.Errorf("unexpected %s log: %s", ent.Level.CapitalString(), ent.Message)
+		}
+		return nil
+	})
+	log.SetDefaultLogger(failing)
+	log.SetContextFallback(failing)
+	return restore
+}
+
+// testWriter passes each encoded entry to t.Log until the test finishes, and to os.Stderr after that,
+// since testing panics on Log calls made after a test has completed.
+type testWriter struct {
+	t    testing.TB
+	mu   sync.RWMutex
+	done bool
+}
+
+// Write logs p through t.Log without its trailing newline.
+func (w *testWriter) Write(p []byte) (int, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.done {
+		_, err := fmt.Fprintf(os.Stderr, "logged after %s completed: %s", w.t.Name(), p)
+		return len(p), err
+	}
+	w.t.Log(string(bytes.TrimSuffix(p, []byte("\n"))))
+	return len(p), nil
+}
+
+// finish makes later writes go to os.Stderr; it waits for writes in progress.
+func (w *testWriter) finish() {
+	w.mu.Lock()
+	w.done = true
+	w.mu.Unlock()
+}
+
+// NewTestLogger returns a Logger writing uncolored console lines at TraceLevel through t.Log, so
+// output is only shown when the test fails or runs with -v. t.Log attributes every line to this
+// package; the caller of each entry is part of the line. Entries logged after the test finishes,
+// e.g. by goroutines it left running, are written to os.Stderr instead.
+func NewTestLogger(t testing.TB) log.Logger {
+	w := &testWriter{t: t}
+	t.Cleanup(w.finish)
+	l, err := log.NewWriterLogger(&log.Config{DisableColor: true, Level: "trace"}, w)
+	if err != nil {
+		t.Fatalf("cannot build test logger: %v", err)
+	}
+	return l
+}
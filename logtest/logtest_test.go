@@ -0,0 +1,86 @@
+package logtest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	log "github.com/vadymlab/go-logger"
+)
+
+// fakeTB records the failures reported through Errorf and the lines passed to Log
+type fakeTB struct {
+	testing.TB
+	errors []string
+	logs   []string
+	clean  []func()
+}
+
+func (f *fakeTB) Helper()                 {}
+func (f *fakeTB) Name() string            { return "fake" }
+func (f *fakeTB) Cleanup(fn func())       { f.clean = append(f.clean, fn) }
+func (f *fakeTB) Log(args ...interface{}) { f.logs = append(f.logs, fmt.Sprint(args...)) }
+func (f *fakeTB) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+// Test FailOnError to verify Error logs fail the test while lower levels do not, and globals are restored
+func TestFailOnError(t *testing.T) {
+	t.Cleanup(log.SaveState())
+	buf := &bytes.Buffer{}
+	base, err := log.NewWriterLogger(&log.Config{IsJson: true}, buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.SetDefaultLogger(base)
+
+	tb := &fakeTB{}
+	restore := FailOnError(tb)
+	log.GetDefaultLogger().Warn("tolerated")
+	log.FromContext(context.Background()).Errorw("database unreachable", "attempt", 3)
+	restore()
+
+	if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], "database unreachable") {
+		t.Errorf("Expected one failure for the error entry, got %q", tb.errors)
+	}
+	if len(tb.clean) != 1 {
+		t.Errorf("Expected the restore function to be registered for cleanup, got %d", len(tb.clean))
+	}
+	if log.GetDefaultLogger() != base {
+		t.Error("Expected the previous default logger to be restored")
+	}
+	if n := strings.Count(buf.String(), "\n"); n != 2 {
+		t.Errorf("Expected both entries to be written, got %q", buf.String())
+	}
+}
+
+// Test NewTestLogger to verify entries, including context fields, are written through t.Log until the test finishes
+func TestNewTestLogger(t *testing.T) {
+	tb := &fakeTB{}
+	logger := NewTestLogger(tb)
+	logger.With("user", "ann").Infow("signed in", "attempt", 2)
+	logger.Debug("details")
+
+	if len(tb.logs) != 2 {
+		t.Fatalf("Expected 2 logged lines, got %q", tb.logs)
+	}
+	if line := tb.logs[0]; !strings.Contains(line, "signed in") || !strings.Contains(line, `"user": "ann"`) ||
+		strings.HasSuffix(line, "\n") {
+		t.Errorf("Expected the entry with its context field, got %q", line)
+	}
+	if !strings.HasPrefix(tb.logs[1], "DEBUG") {
+		t.Errorf("Expected an uncolored debug line, got %q", tb.logs[1])
+	}
+
+	for _, fn := range tb.clean {
+		fn()
+	}
+	logger.Info("after the test")
+	if len(tb.logs) != 2 {
+		t.Errorf("Expected no t.Log calls after cleanup, got %q", tb.logs)
+	}
+
+	NewTestLogger(t).Info("visible with -v")
+}
@@ -0,0 +1,69 @@
+package log
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// ObservedEntry is an entry recorded by an observer logger, with its fields decoded into a map.
+type ObservedEntry struct {
+	Level   LogLevel
+	Message string
+	Fields  map[string]interface{} // Context and entry fields; integers decode as int64.
+	Time    time.Time
+}
+
+// ObservedLogs holds the entries recorded by an observer logger. It is safe for concurrent use.
+type ObservedLogs struct {
+	logs *observer.ObservedLogs
+}
+
+// NewObserverLogger returns a logger that records entries at or above level in memory instead of
+// writing them, for tests asserting on what was logged.
+func NewObserverLogger(level LogLevel) (Logger, *ObservedLogs, error) {
+	levels, err := newLevelState(level)
+	if err != nil {
+		return nil, nil, err
+	}
+	core, logs := observer.New(levels.atom)
+	logger := zap.New(&dropCore{core}, zap.AddCaller()).Sugar()
+	return &zapLogger{
+		log:        *logger,
+		plain:      &desugaredCache{},
+		traceLevel: TraceLevel == level,
+		configured: true,
+		levels:     levels,
+		root:       logger,
+	}, &ObservedLogs{logs: logs}, nil
+}
+
+// Len returns the number of recorded entries.
+func (o *ObservedLogs) Len() int {
+	return o.logs.Len()
+}
+
+// All returns a copy of the recorded entries in the order they were logged.
+func (o *ObservedLogs) All() []ObservedEntry {
+	return toObservedEntries(o.logs.All())
+}
+
+// TakeAll returns the recorded entries and clears them.
+func (o *ObservedLogs) TakeAll() []ObservedEntry {
+	return toObservedEntries(o.logs.TakeAll())
+}
+
+// toObservedEntries converts zap's recorded entries.
+func toObservedEntries(logged []observer.LoggedEntry) []ObservedEntry {
+	entries := make([]ObservedEntry, len(logged))
+	for i, e := range logged {
+		entries[i] = ObservedEntry{
+			Level:   fromZapLevel(e.Level),
+			Message: e.Message,
+			Fields:  e.ContextMap(),
+			Time:    e.Time,
+		}
+	}
+	return entries
+}
@@ -0,0 +1,30 @@
+package log
+
+import (
+	"testing"
+)
+
+// Test NewObserverLogger to verify entries are recorded with typed level, message and fields
+func TestNewObserverLogger(t *testing.T) {
+	logger, logs, err := NewObserverLogger(InfoLevel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.WithField("service", "api").Errorw("boom", "code", 42)
+	logger.Debug("filtered")
+
+	entries := logs.TakeAll()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+	e := entries[0]
+	if e.Level != ErrorLevel || e.Message != "boom" || e.Time.IsZero() {
+		t.Errorf("Unexpected observed entry: %+v", e)
+	}
+	if e.Fields["code"] != int64(42) || e.Fields["service"] != "api" {
+		t.Errorf("Expected code and service fields, got %v", e.Fields)
+	}
+	if logs.Len() != 0 {
+		t.Errorf("Expected TakeAll to clear the entries, got %d left", logs.Len())
+	}
+}
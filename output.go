@@ -0,0 +1,227 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// OutputStdout is the output path writing to the standard output. It is the default.
+	OutputStdout = "stdout"
+	// OutputStderr is the output path writing to the standard error.
+	OutputStderr = "stderr"
+)
+
+// Sink is one output of a Config with Sinks, written with its own encoding, level and destinations.
+// Settings not listed here, such as fields, the clock and sampling, come from the enclosing Config.
+type Sink struct {
+	Level       string   // Level is the minimum severity written to the sink (default Config.Level).
+	IsJson      bool     // IsJson writes the sink as JSON instead of console output.
+	Encoding    Encoding // Encoding selects a preset layout for the sink, as Config.Encoding does.
+	OutputPaths []string // OutputPaths lists the destinations of the sink, as Config.OutputPaths does (default "stdout").
+}
+
+// newSinksLogger builds a logger writing every entry to each of conf.Sinks that enables its level,
+// such as console output on stdout at Info next to a JSON file at Debug. level is the default of
+// sinks without a Level. SetLevel and Check apply on top of the sink levels. GlobalSequence and
+// SampleRules apply to the combined output, so every sink sees the same entries and numbers.
+func newSinksLogger(conf *Config, level LogLevel) (Logger, error) {
+	cores := make([]zapcore.Core, 0, len(conf.Sinks))
+	var files []*fileSink
+	closeFiles := func() {
+		for _, f := range files {
+			_ = f.Close()
+		}
+	}
+	verbose := PanicLevel
+	for i, s := range conf.Sinks {
+		sinkLevel := level
+		if s.Level != "" {
+			parsed, err := ParseLevel(s.Level)
+			if err != nil {
+				closeFiles()
+				return nil, fmt.Errorf("sink %d: %w", i, err)
+			}
+			sinkLevel = parsed
+		}
+		sub := *conf
+		sub.Sinks, sub.GlobalSequence, sub.SampleRules = nil, false, nil
+		sub.IsJson, sub.Encoding, sub.OutputPaths = s.IsJson, s.Encoding, s.OutputPaths
+		l, err := newZapFromConfig(&sub, sinkLevel)
+		if err != nil {
+			closeFiles()
+			return nil, fmt.Errorf("sink %d: %w", i, err)
+		}
+		zl := l.(*zapLogger)
+		files = append(files, zl.files...)
+		// The sink cores carry the base fields; the global rate limit applies once, to the tee.
+		cores = append(cores, &levelGateCore{Core: withoutRateLimit(zl.root.Desugar().Core()), min: zl.levels})
+		if resolveLevel(sinkLevel) > verbose {
+			verbose = resolveLevel(sinkLevel)
+		}
+	}
+
+	levels, err := newLevelState(verbose)
+	if err != nil {
+		closeFiles()
+		return nil, err
+	}
+	var core zapcore.Core = &levelGateCore{Core: zapcore.NewTee(cores...), min: levels}
+	if conf.GlobalSequence {
+		core = newTransformCore(core, addGlobalSequence)
+	}
+	if len(conf.SampleRules) > 0 {
+		rules, err := compileSampleRules(conf.SampleRules)
+		if err != nil {
+			closeFiles()
+			return nil, err
+		}
+		core = &samplerCore{Core: core, rules: rules}
+	}
+	core = &rateLimitCore{core}
+	logger := zap.New(core, zapOptions(conf, core)...).Sugar()
+	return &zapLogger{
+		log:        *logger,
+		plain:      &desugaredCache{},
+		traceLevel: verbose == TraceLevel,
+		configured: true,
+		levels:     levels,
+		dev:        conf.Development,
+		root:       logger,
+		ctxKeys:    append([]string(nil), conf.ContextKeys...),
+		baggage:    conf.BaggageMembers,
+		files:      files,
+		strict:     conf.StrictFields,
+		required:   append([]string(nil), conf.RequiredContextKeys...),
+		escapeDots: conf.NameSeparator != "",
+		base:       baseFields(conf),
+	}, nil
+}
+
+// openOutputs opens every output path and combines them into a single sink. Paths other than
+// OutputStdout and OutputStderr are files, created if needed and appended to, and are also
+// returned so they can be reopened. Files are opened up front, so an unwritable path fails
+// construction with an error naming it instead of failing on the first write.
+func openOutputs(paths []string) (zapcore.WriteSyncer, []*fileSink, error) {
+	if len(paths) == 0 {
+		return zapcore.Lock(os.Stdout), nil, nil
+	}
+	sinks := make([]zapcore.WriteSyncer, 0, len(paths))
+	var files []*fileSink
+	for _, path := range paths {
+		switch path {
+		case OutputStdout:
+			sinks = append(sinks, zapcore.Lock(os.Stdout))
+		case OutputStderr:
+			sinks = append(sinks, zapcore.Lock(os.Stderr))
+		default:
+			f, err := openFileSink(path)
+			if err != nil {
+				for _, opened := range files {
+					_ = opened.Close()
+				}
+				return nil, nil, fmt.Errorf("cannot open output path %q: %w", path, err)
+			}
+			sinks = append(sinks, f)
+			files = append(files, f)
+		}
+	}
+	if len(sinks) == 1 {
+		return sinks[0], files, nil
+	}
+	return zapcore.NewMultiWriteSyncer(sinks...), files, nil
+}
+
+// fileSink is a file output that can be reopened at the same path, e.g. after log rotation.
+type fileSink struct {
+	mu     sync.Mutex
+	path   string
+	f      *os.File
+	closed bool
+}
+
+// openFileSink opens path for appending, creating it if needed.
+func openFileSink(path string) (*fileSink, error) {
+	f, err := openLogFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &fileSink{path: path, f: f}, nil
+}
+
+// openLogFile opens path for appending, creating it if needed.
+func openLogFile(path string) (*os.File, error) {
+	return os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+}
+
+// Write appends p to the current file.
+func (s *fileSink) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.f.Write(p)
+}
+
+// Sync flushes the current file.
+func (s *fileSink) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.f.Sync()
+}
+
+// Reopen opens the path again and switches writes to the new file before closing the old one.
+// If the path cannot be opened, writes continue to the old file. A closed sink is not reopened.
+func (s *fileSink) Reopen() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	f, err := openLogFile(s.path)
+	if err != nil {
+		return err
+	}
+	old := s.f
+	s.f = f
+	return old.Close()
+}
+
+// Close closes the current file for good.
+func (s *fileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return s.f.Close()
+}
+
+// Reopener is implemented by loggers that write to files, such as those built by NewLogger, and
+// can reopen them after log rotation.
+type Reopener interface {
+	// Reopen reopens file outputs at their configured paths.
+	Reopen() error
+}
+
+// Reopen closes and reopens the file outputs of the logger at their configured paths, so writes
+// go to a new file after an external tool such as logrotate renamed the old one. Loggers without
+// file outputs do nothing. The usual wiring is a SIGHUP handler:
+//
+//	hup := make(chan os.Signal, 1)
+//	signal.Notify(hup, syscall.SIGHUP)
+//	go func() {
+//		for range hup {
+//			if err := logger.(log.Reopener).Reopen(); err != nil {
+//				logger.Errorw("failed to reopen log file", "error", err)
+//			}
+//		}
+//	}()
+func (l *zapLogger) Reopen() error {
+	var err error
+	for _, f := range l.files {
+		err = multierr.Append(err, f.Reopen())
+	}
+	return err
+}
@@ -0,0 +1,180 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Test OutputPaths to verify entries are written to a configured file
+func TestOutputPaths_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger, err := NewLogger(&Config{IsJson: true, Level: "INFO", OutputPaths: []string{path}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("to file")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "to file") {
+		t.Errorf("Expected the entry in %s, got %q", path, data)
+	}
+}
+
+// Test Reopen to verify writes move to a new file after the old one was renamed
+func TestZapLogger_Reopen(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	rotated := filepath.Join(dir, "app.log.1")
+	logger, err := NewLogger(&Config{IsJson: true, Level: "INFO", OutputPaths: []string{path}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("before rotation")
+
+	if err := os.Rename(path, rotated); err != nil {
+		t.Fatal(err)
+	}
+	if err := logger.(Reopener).Reopen(); err != nil {
+		t.Fatalf("Reopen returned error: %v", err)
+	}
+	logger.Info("after rotation")
+
+	current, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	old, err := os.ReadFile(rotated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(current), "after rotation") || strings.Contains(string(current), "before rotation") {
+		t.Errorf("Expected only the new line in the reopened file, got %q", current)
+	}
+	if !strings.Contains(string(old), "before rotation") || strings.Contains(string(old), "after rotation") {
+		t.Errorf("Expected only the old line in the rotated file, got %q", old)
+	}
+}
+
+// Test OutputPaths to verify a path in a missing directory fails construction with an error naming it
+func TestOutputPaths_Unwritable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "app.log")
+	_, err := NewLogger(&Config{OutputPaths: []string{"stdout", path}})
+	if err == nil {
+		t.Fatal("Expected an error for an unwritable output path")
+	}
+	if !strings.Contains(err.Error(), `"`+path+`"`) {
+		t.Errorf("Expected the error to name %q, got %v", path, err)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected the underlying error to be kept, got %v", err)
+	}
+}
+
+// Test Sinks to verify entries reach each sink with its own encoding and level
+func TestSinks(t *testing.T) {
+	dir := t.TempDir()
+	console := filepath.Join(dir, "console.log")
+	jsonPath := filepath.Join(dir, "app.json")
+	logger, err := NewLogger(&Config{
+		Level:      "INFO",
+		IncludePID: true,
+		Sinks: []Sink{
+			{Encoding: EncodingPlain, OutputPaths: []string{console}},
+			{IsJson: true, Level: "DEBUG", OutputPaths: []string{jsonPath}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.Debug("debug only")
+	logger.Warn("everywhere")
+
+	text, err := os.ReadFile(console)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(text), "debug only") || !strings.Contains(string(text), "WARN") {
+		t.Errorf("Expected only the Warn entry in console encoding, got %q", text)
+	}
+
+	data, err := os.ReadFile(jsonPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := decodeEntries(t, string(data))
+	if len(entries) != 2 || entries[0]["message"] != "debug only" || entries[1]["severity"] != "warn" {
+		t.Fatalf("Expected the Debug and Warn entries as JSON, got %q", data)
+	}
+	if entries[1]["pid"] != float64(os.Getpid()) {
+		t.Errorf("Expected the base fields on sink entries, got %v", entries[1])
+	}
+	if n := strings.Count(string(data), `"pid"`); n != 2 {
+		t.Errorf("Expected the pid field once per JSON line, got %d in %q", n, data)
+	}
+	if n := strings.Count(string(text), "pid"); n != 1 {
+		t.Errorf("Expected the pid field once on the console line, got %d in %q", n, text)
+	}
+}
+
+// Test Sinks to verify SetLevel applies on top of the sink levels
+func TestSinks_SetLevel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.json")
+	logger, err := NewLogger(&Config{Level: "TRACE", Sinks: []Sink{{IsJson: true, OutputPaths: []string{path}}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.(*zapLogger).Trace("traced")
+	if err := logger.SetLevel(WarnLevel); err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("dropped")
+	logger.Error("kept")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := decodeEntries(t, string(data))
+	if len(entries) != 2 || entries[0]["severity"] != "trace" || entries[1]["message"] != "kept" {
+		t.Errorf("Expected the Trace and Error entries, got %q", data)
+	}
+}
+
+// Test Sinks to verify GlobalSequence and SampleRules decide once per entry, not once per sink
+func TestSinks_OncePerEntry(t *testing.T) {
+	dir := t.TempDir()
+	paths := []string{filepath.Join(dir, "a.json"), filepath.Join(dir, "b.json")}
+	logger, err := NewLogger(&Config{
+		GlobalSequence: true,
+		SampleRules:    []SampleRule{{LevelAtMost: InfoLevel, MessagePattern: "^noise$", KeepEveryN: 2}},
+		Sinks:          []Sink{{IsJson: true, OutputPaths: paths[:1]}, {IsJson: true, OutputPaths: paths[1:]}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 4; i++ {
+		logger.Info("noise")
+	}
+	logger.Warn("kept")
+
+	var seqs [2][]interface{}
+	for i, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, entry := range decodeEntries(t, string(data)) {
+			seqs[i] = append(seqs[i], entry["global_seq"])
+		}
+	}
+	if len(seqs[0]) != 3 || fmt.Sprint(seqs[0]) != fmt.Sprint(seqs[1]) {
+		t.Errorf("Expected the same 3 sampled entries and numbers in both sinks, got %v and %v", seqs[0], seqs[1])
+	}
+}
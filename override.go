@@ -0,0 +1,79 @@
+package log
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LevelOverrideEnv is the environment variable read by WatchLevelOverride.
+const LevelOverrideEnv = "LOG_LEVEL_OVERRIDE"
+
+// WatchLevelOverride applies the level named by the LOG_LEVEL_OVERRIDE environment variable to l,
+// re-reading it every interval and, on Unix, whenever the process receives SIGUSR1. While the
+// variable is unset or not a known level, l keeps the level it had when the watch started.
+// A non-positive interval only reacts to the signal. The returned function stops watching and
+// leaves the current level in place.
+func WatchLevelOverride(l Logger, interval time.Duration) func() {
+	base := effectiveLevel(l)
+	reload := make(chan os.Signal, 1)
+	stopNotify := notifyLevelReload(reload)
+
+	var tick <-chan time.Time
+	var ticker *time.Ticker
+	if interval > 0 {
+		ticker = time.NewTicker(interval)
+		tick = ticker.C
+	}
+
+	applyLevelOverride(l, base)
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		for {
+			select {
+			case <-done:
+				return
+			case <-tick:
+				applyLevelOverride(l, base)
+			case <-reload:
+				applyLevelOverride(l, base)
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			stopNotify()
+			if ticker != nil {
+				ticker.Stop()
+			}
+			close(done)
+			<-finished
+		})
+	}
+}
+
+// applyLevelOverride sets the level named by LOG_LEVEL_OVERRIDE, or base when it is unset or unknown.
+func applyLevelOverride(l Logger, base LogLevel) {
+	level := base
+	if name := strings.TrimSpace(os.Getenv(LevelOverrideEnv)); name != "" {
+		if parsed, err := ParseLevel(name); err == nil {
+			level = parsed
+		}
+	}
+	_ = l.SetLevel(level)
+}
+
+// effectiveLevel returns the most verbose level enabled on l.
+func effectiveLevel(l Logger) LogLevel {
+	for level := TraceLevel; level > PanicLevel; level-- {
+		if l.Check(level) {
+			return level
+		}
+	}
+	return PanicLevel
+}
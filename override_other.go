@@ -0,0 +1,12 @@
+//go:build !unix
+
+package log
+
+import (
+	"os"
+)
+
+// notifyLevelReload does nothing on platforms without SIGUSR1; only the interval applies.
+func notifyLevelReload(chan<- os.Signal) func() {
+	return func() {}
+}
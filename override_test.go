@@ -0,0 +1,31 @@
+package log
+
+import (
+	"testing"
+	"time"
+)
+
+// waitForLevel polls until l reports level as its most verbose enabled level
+func waitForLevel(t *testing.T, l Logger, level LogLevel) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for effectiveLevel(l) != level {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected level %d, still at %d", level, effectiveLevel(l))
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// Test WatchLevelOverride to verify the ticker applies the override and falls back when it is unset
+func TestWatchLevelOverride_Interval(t *testing.T) {
+	logger, _ := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	t.Setenv(LevelOverrideEnv, "")
+	stop := WatchLevelOverride(logger, 10*time.Millisecond)
+	t.Cleanup(stop)
+
+	t.Setenv(LevelOverrideEnv, "debug")
+	waitForLevel(t, logger, DebugLevel)
+	t.Setenv(LevelOverrideEnv, "bogus")
+	waitForLevel(t, logger, InfoLevel)
+}
@@ -0,0 +1,15 @@
+//go:build unix
+
+package log
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyLevelReload delivers SIGUSR1 to ch until the returned function is called.
+func notifyLevelReload(ch chan<- os.Signal) func() {
+	signal.Notify(ch, syscall.SIGUSR1)
+	return func() { signal.Stop(ch) }
+}
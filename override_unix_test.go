@@ -0,0 +1,23 @@
+//go:build unix
+
+package log
+
+import (
+	"os"
+	"syscall"
+	"testing"
+)
+
+// Test WatchLevelOverride to verify SIGUSR1 re-reads the environment and updates the level
+func TestWatchLevelOverride_Signal(t *testing.T) {
+	logger, _ := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	t.Setenv(LevelOverrideEnv, "")
+	stop := WatchLevelOverride(logger, 0)
+	t.Cleanup(stop)
+
+	t.Setenv(LevelOverrideEnv, "TRACE")
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+	waitForLevel(t, logger, TraceLevel)
+}
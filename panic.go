@@ -0,0 +1,110 @@
+package log
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// syncThenPanic is a zapcore.CheckWriteHook that flushes the output before panicking,
+// so the panic entry is not lost in a buffered sink while the stack unwinds.
+type syncThenPanic struct {
+	sync func() error
+}
+
+// OnWrite flushes the output and panics with the entry message.
+func (h syncThenPanic) OnWrite(ce *zapcore.CheckedEntry, _ []zapcore.Field) {
+	_ = h.sync()
+	panic(ce.Message)
+}
+
+// addPanicStacktrace attaches the captured stack to panic entries as a "stacktrace" field.
+func addPanicStacktrace(ent zapcore.Entry, fields []zapcore.Field) (zapcore.Entry, []zapcore.Field) {
+	if (ent.Level == zapcore.PanicLevel || ent.Level == zapcore.DPanicLevel) && ent.Stack != "" {
+		fields = appendFields(fields, zap.String("stacktrace", ent.Stack))
+	}
+	return ent, fields
+}
+
+// stackTruncatedMarker ends stacks cut by Config.StacktraceMaxFrames.
+const stackTruncatedMarker = "…(truncated)"
+
+// truncateStack keeps the first max frames of a stack in the format of zap and debug.Stack, where
+// each frame is a function line followed by an indented file line, and marks the cut.
+func truncateStack(stack string, max int) string {
+	lines := strings.Split(stack, "\n")
+	frames := 0
+	for i, line := range lines {
+		if line == "" || strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "goroutine ") {
+			continue
+		}
+		frames++
+		if frames > max {
+			return strings.Join(lines[:i], "\n") + "\n" + stackTruncatedMarker
+		}
+	}
+	return stack
+}
+
+// stackLimiter returns a transform truncating the entry stack and the "stacktrace" and "stack"
+// fields written by PanicStacktrace, LogPanic and LogStackIf to max frames.
+func stackLimiter(max int) entryTransform {
+	return func(ent zapcore.Entry, fields []zapcore.Field) (zapcore.Entry, []zapcore.Field) {
+		ent.Stack = truncateStack(ent.Stack, max)
+		copied := false
+		for i, f := range fields {
+			if f.Type != zapcore.StringType || (f.Key != "stacktrace" && f.Key != "stack") {
+				continue
+			}
+			if cut := truncateStack(f.String, max); cut != f.String {
+				if !copied {
+					// Leave the caller's fields untouched.
+					fields = append([]zapcore.Field(nil), fields...)
+					copied = true
+				}
+				fields[i].String = cut
+			}
+		}
+		return ent, fields
+	}
+}
+
+// LogPanic logs a recovered panic value at Error with its Go type in "panic_type", the value in
+// "panic" and the stack of the panicking goroutine in "stacktrace". Call it from the deferred
+// function that recovered:
+//
+//	defer func() {
+//		if r := recover(); r != nil {
+//			log.LogPanic(logger, r)
+//		}
+//	}()
+func LogPanic(l Logger, recovered interface{}) {
+	// Report the deferred function rather than this helper as the caller.
+	l.SkipCallers(1).Errorw("recovered panic",
+		"panic_type", fmt.Sprintf("%T", recovered),
+		"panic", recovered,
+		"stacktrace", string(debug.Stack()),
+	)
+}
+
+// LogStackIf logs msg at level with the stack of the calling goroutine in a "stack" field when
+// cond is true, and does nothing otherwise. The stack is only captured when the entry is enabled.
+// PanicLevel logs at Error without panicking; TraceLevel writes a trace entry.
+func (l *zapLogger) LogStackIf(cond bool, level LogLevel, msg string) {
+	if !cond || !l.Check(level) {
+		return
+	}
+	stack := zap.String("stack", string(debug.Stack()))
+	if level == TraceLevel {
+		trace(l, msg, stack)
+		return
+	}
+	lvl := zapcore.ErrorLevel
+	if converted := convLevel(level); converted != nil {
+		lvl = *converted
+	}
+	l.log.WithOptions(options...).Desugar().Log(lvl, msg, stack)
+}
@@ -0,0 +1,191 @@
+package log
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// syncBuffer is a WriteSyncer recording whether it was flushed
+type syncBuffer struct {
+	bytes.Buffer
+	synced bool
+}
+
+func (b *syncBuffer) Sync() error {
+	b.synced = true
+	return nil
+}
+
+// Test Panic to verify the entry is logged with fields and stacktrace and flushed before the panic propagates
+func TestZapLogger_Panic(t *testing.T) {
+	buf := &syncBuffer{}
+	logger, err := buildZap(&Config{IsJson: true, PanicSync: true, PanicStacktrace: true}, InfoLevel, buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != "boom 42" {
+				t.Errorf("Expected panic with %q, got %v", "boom 42", r)
+			}
+			if !buf.synced {
+				t.Error("Expected output to be flushed before the panic propagated")
+			}
+		}()
+		logger.WithField("key", "value").(*zapLogger).Panicf("boom %d", 42)
+	}()
+
+	entry := decodeEntry(t, &buf.Buffer)
+	if entry["message"] != "boom 42" || entry["key"] != "value" {
+		t.Errorf("Expected panic entry with message and fields, got %v", entry)
+	}
+	if stack, _ := entry["stacktrace"].(string); !strings.Contains(stack, "TestZapLogger_Panic") {
+		t.Errorf("Expected stacktrace to contain the test function, got %q", stack)
+	}
+}
+
+// Test DPanic to verify it logs an error in production and panics in development
+func TestZapLogger_DPanic(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	logger.DPanic("invariant broken")
+
+	entry := decodeEntry(t, buf)
+	if entry["message"] != "invariant broken" || entry["severity"] != "error" {
+		t.Errorf("Expected error entry without panic, got %v", entry)
+	}
+
+	devLogger, _ := newBufferedZap(t, &Config{IsJson: true, Development: true}, InfoLevel)
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected DPanic to panic in development mode")
+		}
+	}()
+	devLogger.DPanicf("invariant %s", "broken")
+}
+
+// quotaError is a custom error type used to check the recorded panic type
+type quotaError struct{ limit int }
+
+func (e *quotaError) Error() string { return "quota exceeded" }
+
+// Test LogPanic to verify the recovered value is logged with its type and stack
+func TestLogPanic(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				LogPanic(logger, r)
+			}
+		}()
+		panic(&quotaError{limit: 10})
+	}()
+
+	entry := decodeEntry(t, buf)
+	if entry["severity"] != "error" || entry["panic_type"] != "*log.quotaError" || entry["panic"] != "quota exceeded" {
+		t.Errorf("Expected typed panic entry, got %v", entry)
+	}
+	if stack, _ := entry["stacktrace"].(string); !strings.Contains(stack, "TestLogPanic") {
+		t.Errorf("Expected stacktrace to include the panicking function, got %q", stack)
+	}
+}
+
+// Test Panic to verify buffered output is flushed before the panic propagates, without PanicSync
+func TestZapLogger_PanicFlushesBuffer(t *testing.T) {
+	var buf bytes.Buffer
+	buffered := &zapcore.BufferedWriteSyncer{WS: zapcore.AddSync(&buf), FlushInterval: time.Hour}
+	t.Cleanup(func() { _ = buffered.Stop() })
+	logger, err := buildZap(&Config{IsJson: true}, InfoLevel, buffered)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != "boom 42" {
+				t.Errorf("Expected panic with %q, got %v", "boom 42", r)
+			}
+			if !strings.Contains(buf.String(), "boom 42") {
+				t.Errorf("Expected the panic entry to be written before the panic propagated, got %q", buf.String())
+			}
+		}()
+		logger.Panic("boom ", 42)
+	}()
+}
+
+// Test LogStackIf to verify the stack field is attached only when the condition holds
+func TestZapLogger_LogStackIf(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+
+	logger.LogStackIf(false, WarnLevel, "skipped")
+	if buf.Len() != 0 {
+		t.Fatalf("Expected no output for a false condition, got %q", buf.String())
+	}
+
+	logger.LogStackIf(true, WarnLevel, "suspicious")
+	entry := decodeEntry(t, buf)
+	if entry["severity"] != "warn" || entry["message"] != "suspicious" {
+		t.Errorf("Expected a warn entry, got %v", entry)
+	}
+	if stack, _ := entry["stack"].(string); !strings.Contains(stack, "TestZapLogger_LogStackIf") {
+		t.Errorf("Expected the stack of the caller, got %q", stack)
+	}
+}
+
+// Test LogStackIf to verify PanicLevel logs at Error without panicking and TraceLevel writes a trace entry
+func TestZapLogger_LogStackIfLevels(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, TraceLevel)
+
+	logger.LogStackIf(true, PanicLevel, "critical")
+	logger.LogStackIf(true, TraceLevel, "detail")
+	entries := decodeEntries(t, buf.String())
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %v", entries)
+	}
+	for i, severity := range []string{"error", "trace"} {
+		entry := entries[i]
+		if entry["severity"] != severity || entry["stack"] == nil {
+			t.Errorf("Expected a %s entry with the stack, got %v", severity, entry)
+		}
+		if caller, _ := entry["module"].(string); !strings.Contains(caller, "panic_test.go") {
+			t.Errorf("Expected the test file as caller, got %q", caller)
+		}
+	}
+}
+
+// deepStack calls fn from depth nested frames.
+func deepStack(depth int, fn func()) {
+	if depth == 0 {
+		fn()
+		return
+	}
+	deepStack(depth-1, fn)
+}
+
+// Test StacktraceMaxFrames to verify a deep stack is cut to the configured number of frames
+func TestStacktraceMaxFrames(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true, StacktraceMaxFrames: 3}, InfoLevel)
+	deepStack(20, func() { logger.LogStackIf(true, ErrorLevel, "deep") })
+
+	entry := decodeEntry(t, buf)
+	stack, _ := entry["stack"].(string)
+	if !strings.HasSuffix(stack, "\n"+stackTruncatedMarker) {
+		t.Fatalf("Expected the stack to end with the truncation marker, got %q", stack)
+	}
+	frames := 0
+	for _, line := range strings.Split(stack, "\n") {
+		if strings.HasPrefix(line, "\t") {
+			frames++
+		}
+	}
+	if frames != 3 {
+		t.Errorf("Expected 3 frames, got %d in %q", frames, stack)
+	}
+	if !strings.HasPrefix(stack, "goroutine ") {
+		t.Errorf("Expected the goroutine header to be kept, got %q", stack)
+	}
+}
@@ -0,0 +1,79 @@
+package log
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// fieldBufferCap is the capacity of pooled field buffers; larger buffers are not returned to the pool.
+const fieldBufferCap = 16
+
+// fieldPool reuses the field buffers built by the w-variants, which would otherwise be allocated per call.
+var fieldPool = sync.Pool{
+	New: func() interface{} {
+		fields := make([]zap.Field, 0, fieldBufferCap)
+		return &fields
+	},
+}
+
+// desugaredCache holds the non-sugared counterpart of a logger, built on first use.
+type desugaredCache struct {
+	once sync.Once
+	log  *zap.Logger
+}
+
+// setLog replaces the sugared logger and resets the cached non-sugared counterpart.
+func (l *zapLogger) setLog(s *zap.SugaredLogger) {
+	l.log = *s
+	l.plain = &desugaredCache{}
+}
+
+// desugared returns the non-sugared logger used by the w-variants, with the caller skip
+// adjusted for zapLogger.Xw and logw.
+func (l *zapLogger) desugared() *zap.Logger {
+	build := func() *zap.Logger {
+		return l.log.Desugar().WithOptions(zap.AddCallerSkip(2))
+	}
+	if l.plain == nil {
+		return build()
+	}
+	l.plain.once.Do(func() { l.plain.log = build() })
+	return l.plain.log
+}
+
+// enabled reports whether entries at lvl pass the level check, using the cached non-sugared logger
+// so that disabled f-variant calls return before any formatting or logger cloning.
+func (l *zapLogger) enabled(lvl zapcore.Level) bool {
+	return l.desugared().Core().Enabled(lvl)
+}
+
+// logw writes a w-variant entry, converting the key-value pairs into a pooled field buffer that is
+// released once the entry has been written. Malformed pairs are left to the sugared logger, which
+// reports them the usual way, or reported by strictPairs with Config.StrictFields.
+func (l *zapLogger) logw(lvl zapcore.Level, msg string, kvs []interface{}) {
+	if validatePairs(kvs) != nil {
+		kvs = l.strictPairs(kvs, 2)
+		l.log.WithOptions(zap.AddCallerSkip(2)).Logw(lvl, msg, kvs...)
+		return
+	}
+	ce := l.desugared().Check(lvl, msg)
+	if ce == nil {
+		return
+	}
+
+	buf := fieldPool.Get().(*[]zap.Field)
+	fields := *buf
+	for i := 0; i < len(kvs); i += 2 {
+		fields = append(fields, zap.Any(kvs[i].(string), kvs[i+1]))
+	}
+	ce.Write(fields...)
+
+	if cap(fields) > fieldBufferCap {
+		return
+	}
+	clear(fields)
+	*buf = fields[:0]
+	fieldPool.Put(buf)
+}
@@ -0,0 +1,65 @@
+package log
+
+import (
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// protoValue renders a protobuf message with protojson when the entry is encoded.
+type protoValue struct {
+	msg proto.Message
+}
+
+// MarshalJSON renders the message in its canonical JSON mapping.
+func (p protoValue) MarshalJSON() ([]byte, error) {
+	return protojson.Marshal(p.msg)
+}
+
+// ProtoLogger is implemented by loggers that can attach protobuf messages, such as those built by
+// NewLogger.
+type ProtoLogger interface {
+	// WithProto attaches a protobuf message as structured JSON, clearing the redacted field paths.
+	WithProto(key string, msg proto.Message, redact ...string) Logger
+}
+
+// WithProto attaches msg under key in its protojson form. The fields named in redact are cleared
+// first; nested fields are addressed with dots, e.g. "user.email", using proto or JSON field names.
+// The message is copied, so later changes to msg do not affect the field.
+func (l *zapLogger) WithProto(key string, msg proto.Message, redact ...string) Logger {
+	return l.With(protoField(key, msg, redact...))
+}
+
+// protoField returns a field rendering a copy of msg with the redacted paths cleared.
+func protoField(key string, msg proto.Message, redact ...string) zap.Field {
+	if msg == nil {
+		return zap.Reflect(key, nil)
+	}
+	clone := proto.Clone(msg)
+	for _, path := range redact {
+		clearProtoPath(clone.ProtoReflect(), strings.Split(path, "."))
+	}
+	return zap.Reflect(key, protoValue{clone})
+}
+
+// clearProtoPath clears the field at path within m. Unknown fields and unset parents are ignored.
+func clearProtoPath(m protoreflect.Message, path []string) {
+	fields := m.Descriptor().Fields()
+	fd := fields.ByName(protoreflect.Name(path[0]))
+	if fd == nil {
+		fd = fields.ByJSONName(path[0])
+	}
+	if fd == nil {
+		return
+	}
+	if len(path) == 1 {
+		m.Clear(fd)
+		return
+	}
+	if fd.Message() != nil && !fd.IsList() && !fd.IsMap() && m.Has(fd) {
+		clearProtoPath(m.Mutable(fd).Message(), path[1:])
+	}
+}
@@ -0,0 +1,49 @@
+package log
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/types/known/apipb"
+	"google.golang.org/protobuf/types/known/sourcecontextpb"
+)
+
+// Test WithProto to verify a message renders as its protojson form under the key
+func TestZapLogger_WithProto(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	msg := &apipb.Api{Name: "checkout", Version: "v1"}
+
+	scoped := logger.WithProto("api", msg)
+	msg.Version = "v2"
+	scoped.Info("called")
+
+	entry := decodeEntry(t, buf)
+	api, _ := entry["api"].(map[string]interface{})
+	if api["name"] != "checkout" || api["version"] != "v1" {
+		t.Errorf("Expected the message as of the call, got %v", entry["api"])
+	}
+}
+
+// Test WithProto to verify redacted paths are cleared, including nested fields by JSON name
+func TestZapLogger_WithProtoRedact(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	msg := &apipb.Api{
+		Name:          "checkout",
+		Version:       "v1",
+		SourceContext: &sourcecontextpb.SourceContext{FileName: "internal/checkout.proto"},
+	}
+
+	var l Logger = logger
+	l.(ProtoLogger).WithProto("api", msg, "version", "sourceContext.file_name", "missing").Info("called")
+
+	entry := decodeEntry(t, buf)
+	api, _ := entry["api"].(map[string]interface{})
+	if api["name"] != "checkout" || api["version"] != nil {
+		t.Errorf("Expected version to be redacted, got %v", entry["api"])
+	}
+	if ctx, _ := api["sourceContext"].(map[string]interface{}); len(ctx) != 0 {
+		t.Errorf("Expected the nested file name to be redacted, got %v", api["sourceContext"])
+	}
+	if msg.Version != "v1" || msg.SourceContext.FileName == "" {
+		t.Error("Expected the original message to be left untouched")
+	}
+}
@@ -0,0 +1,114 @@
+package log
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// rateBucket is a token bucket allowing rate entries per second, with bursts of up to rate entries.
+type rateBucket struct {
+	mu      sync.Mutex
+	rate    float64
+	tokens  float64
+	last    time.Time
+	now     func() time.Time
+	dropped atomic.Uint64
+}
+
+// globalRate is the bucket shared by every logger built by the package; nil means unlimited.
+var globalRate atomic.Pointer[rateBucket]
+
+// newRateBucket creates a full bucket refilling at perSecond tokens per second.
+func newRateBucket(perSecond int, now func() time.Time) *rateBucket {
+	return &rateBucket{rate: float64(perSecond), tokens: float64(perSecond), last: now(), now: now}
+}
+
+// allow takes a token if one is available and counts the entry as dropped otherwise.
+func (b *rateBucket) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.now()
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(b.rate, b.tokens+elapsed.Seconds()*b.rate)
+		b.last = now
+	}
+	if b.tokens < 1 {
+		b.dropped.Add(1)
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// SetGlobalRateLimit limits the entries below Error written by all loggers of the process together
+// to perSecond per second, allowing bursts of the same size, and drops the excess. Error and more
+// severe entries are never dropped. A perSecond of zero or less removes the limit.
+func SetGlobalRateLimit(perSecond int) {
+	if perSecond <= 0 {
+		globalRate.Store(nil)
+		return
+	}
+	globalRate.Store(newRateBucket(perSecond, time.Now))
+}
+
+// GlobalRateLimitDropped returns the number of entries dropped by the limit set with the latest
+// call to SetGlobalRateLimit.
+func GlobalRateLimitDropped() uint64 {
+	if b := globalRate.Load(); b != nil {
+		return b.dropped.Load()
+	}
+	return 0
+}
+
+// rateLimitCore wraps a zapcore.Core and drops entries below Error while the global bucket is empty.
+// It is the outermost core of a logger, so each entry costs one token however many outputs it
+// reaches, and the token is taken only once level checks, filters and sampling accepted the entry.
+// Trace entries skip Check and take their token in trace.
+type rateLimitCore struct {
+	zapcore.Core
+}
+
+// With preserves the rate limit on cores derived with additional fields.
+func (c *rateLimitCore) With(fields []zapcore.Field) zapcore.Core {
+	return &rateLimitCore{c.Core.With(fields)}
+}
+
+// Check takes a token for entries below Error the wrapped core accepted, and drops them when none
+// is left. Hooks registered by the wrapped core, such as ExitOnLevel, are kept.
+func (c *rateLimitCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	b := globalRate.Load()
+	if b == nil || ent.Level >= zapcore.ErrorLevel {
+		return c.Core.Check(ent, ce)
+	}
+	if ce != nil {
+		// Only reached below a tee, where acceptance by the wrapped core cannot be told apart
+		// from the cores already added; take the token up front.
+		if !b.allow() {
+			return ce
+		}
+		return c.Core.Check(ent, ce)
+	}
+	checked := c.Core.Check(ent, nil)
+	if checked != nil && !b.allow() {
+		return nil
+	}
+	return checked
+}
+
+// allowTrace takes a token for a trace entry, which is written without a Check.
+func allowTrace() bool {
+	b := globalRate.Load()
+	return b == nil || b.allow()
+}
+
+// withoutRateLimit returns core without its outermost rateLimitCore, for cores combined under
+// a single limit.
+func withoutRateLimit(core zapcore.Core) zapcore.Core {
+	if rl, ok := core.(*rateLimitCore); ok {
+		return rl.Core
+	}
+	return core
+}
@@ -0,0 +1,110 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Test SetGlobalRateLimit to verify entries beyond the budget are dropped and counted, except errors
+func TestSetGlobalRateLimit(t *testing.T) {
+	t.Cleanup(func() { SetGlobalRateLimit(0) })
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	globalRate.Store(newRateBucket(2, func() time.Time { return now }))
+
+	first, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	second, _ := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	first.Info("one")
+	second.Info("two")
+	first.Info("three")
+	first.Warn("four")
+	first.Error("five")
+
+	if out := buf.String(); strings.Contains(out, "three") || strings.Contains(out, "four") {
+		t.Errorf("Expected entries over the shared budget to be dropped, got %q", out)
+	}
+	if !strings.Contains(buf.String(), "five") {
+		t.Errorf("Expected the Error entry to be exempt, got %q", buf.String())
+	}
+	if got := GlobalRateLimitDropped(); got != 2 {
+		t.Errorf("Expected 2 dropped entries, got %d", got)
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	first.Info("refilled")
+	if !strings.Contains(buf.String(), "refilled") {
+		t.Errorf("Expected a token after half a second, got %q", buf.String())
+	}
+
+	SetGlobalRateLimit(0)
+	if globalRate.Load() != nil || GlobalRateLimitDropped() != 0 {
+		t.Error("Expected SetGlobalRateLimit(0) to remove the limit")
+	}
+}
+
+// Test SetGlobalRateLimit to verify trace lines are limited, tee'd sinks take one token per entry
+// and entries discarded by sampling take none
+func TestSetGlobalRateLimit_OncePerWrittenEntry(t *testing.T) {
+	t.Cleanup(func() { SetGlobalRateLimit(0) })
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	globalRate.Store(newRateBucket(2, clock))
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, TraceLevel)
+	for i := 0; i < 5; i++ {
+		logger.Print("printed")
+	}
+	if n := strings.Count(buf.String(), "printed"); n != 2 || GlobalRateLimitDropped() != 3 {
+		t.Errorf("Expected 2 trace lines and 3 drops, got %d lines and %d drops", n, GlobalRateLimitDropped())
+	}
+
+	globalRate.Store(newRateBucket(2, clock))
+	dir := t.TempDir()
+	paths := []string{filepath.Join(dir, "a.log"), filepath.Join(dir, "b.log")}
+	tee, err := NewLogger(&Config{IsJson: true, Sinks: []Sink{
+		{IsJson: true, OutputPaths: paths[:1]},
+		{IsJson: true, OutputPaths: paths[1:]},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tee.Info("first")
+	tee.Info("second")
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), "second") {
+			t.Errorf("Expected both entries in %s, got %q", path, data)
+		}
+	}
+
+	globalRate.Store(newRateBucket(1, clock))
+	sampled, sampledBuf := newBufferedZap(t, &Config{IsJson: true, SampleRules: []SampleRule{{LevelAtMost: InfoLevel, MessagePattern: "^noise$", KeepEveryN: 100}}}, InfoLevel)
+	sampled.Info("noise")
+	sampled.Info("noise")
+	globalRate.Store(newRateBucket(1, clock))
+	sampled.Info("noise")
+	sampled.Info("real")
+	if !strings.Contains(sampledBuf.String(), "real") {
+		t.Errorf("Expected sampled-out entries to leave the token for the real entry, got %q", sampledBuf.String())
+	}
+}
+
+// Test SetGlobalRateLimit to verify wrappers added to a logger do not make an entry cost two tokens
+func TestSetGlobalRateLimit_Wrapped(t *testing.T) {
+	t.Cleanup(func() { SetGlobalRateLimit(0) })
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	globalRate.Store(newRateBucket(2, func() time.Time { return now }))
+
+	base, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	logger := base.WithPrefix("job: ")
+	logger.Info("one")
+	logger.Info("two")
+	if n := strings.Count(buf.String(), "job: "); n != 2 || GlobalRateLimitDropped() != 0 {
+		t.Errorf("Expected both entries within the budget, got %q", buf.String())
+	}
+}
@@ -0,0 +1,77 @@
+package log
+
+import (
+	"fmt"
+	"regexp"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// SampleRule keeps one in every KeepEveryN entries at or below LevelAtMost whose message matches
+// MessagePattern, a regular expression where an empty pattern matches every message. Trace entries
+// are written without a level check and cannot be sampled, so TraceLevel is rejected.
+type SampleRule struct {
+	LevelAtMost    LogLevel // LevelAtMost is the most severe level the rule applies to, e.g. DebugLevel.
+	MessagePattern string   // MessagePattern selects the messages the rule applies to.
+	KeepEveryN     int      // KeepEveryN keeps the first of every N matching entries; 1 or less keeps all.
+}
+
+// sampleRule is a compiled SampleRule with the number of entries it has matched so far.
+type sampleRule struct {
+	level   zapcore.Level
+	pattern *regexp.Regexp
+	every   uint64
+	seen    atomic.Uint64
+}
+
+// compileSampleRules validates and compiles rules in order.
+func compileSampleRules(rules []SampleRule) ([]*sampleRule, error) {
+	compiled := make([]*sampleRule, 0, len(rules))
+	for i, r := range rules {
+		pattern, err := regexp.Compile(r.MessagePattern)
+		if err != nil {
+			return nil, err
+		}
+		level := convLevel(r.LevelAtMost)
+		if level == nil || resolveLevel(r.LevelAtMost) == TraceLevel {
+			return nil, fmt.Errorf("sample rule %d (%q): level %d cannot be sampled", i, r.MessagePattern, r.LevelAtMost)
+		}
+		every := uint64(1)
+		if r.KeepEveryN > 1 {
+			every = uint64(r.KeepEveryN)
+		}
+		compiled = append(compiled, &sampleRule{level: *level, pattern: pattern, every: every})
+	}
+	return compiled, nil
+}
+
+// samplerCore wraps a zapcore.Core and thins out entries matching the sample rules. The first rule
+// matching an entry decides whether it is kept; entries matching no rule are always kept, as are
+// entries at Error and above. The rule counters are shared by every logger derived from the core.
+type samplerCore struct {
+	zapcore.Core
+	rules []*sampleRule
+}
+
+// With preserves the sampling on cores derived with additional fields.
+func (c *samplerCore) With(fields []zapcore.Field) zapcore.Core {
+	return &samplerCore{Core: c.Core.With(fields), rules: c.rules}
+}
+
+// Check skips the wrapped core for entries the first matching rule samples out.
+func (c *samplerCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if ent.Level >= zapcore.ErrorLevel || !c.Core.Enabled(ent.Level) {
+		return c.Core.Check(ent, ce)
+	}
+	for _, r := range c.rules {
+		if ent.Level > r.level || !r.pattern.MatchString(ent.Message) {
+			continue
+		}
+		if (r.seen.Add(1)-1)%r.every != 0 {
+			return ce
+		}
+		break
+	}
+	return c.Core.Check(ent, ce)
+}
@@ -0,0 +1,56 @@
+package log
+
+import (
+	"strings"
+	"testing"
+)
+
+// Test SampleRules to verify matching debug entries are sampled while errors and other messages pass
+func TestSampleRules(t *testing.T) {
+	conf := &Config{IsJson: true, SampleRules: []SampleRule{
+		{LevelAtMost: DebugLevel, MessagePattern: "^cache ", KeepEveryN: 3},
+	}}
+	logger, buf := newBufferedZap(t, conf, DebugLevel)
+	derived := logger.WithField("shard", 1)
+	for i := 0; i < 6; i++ {
+		derived.Debugw("cache lookup", "i", i)
+		logger.Error("cache failure")
+	}
+	logger.Debug("connection opened")
+	logger.Info("cache warmed")
+
+	counts := map[string]int{}
+	for _, entry := range decodeEntries(t, buf.String()) {
+		counts[entry["message"].(string)]++
+	}
+	expected := map[string]int{"cache lookup": 2, "cache failure": 6, "connection opened": 1, "cache warmed": 1}
+	for msg, want := range expected {
+		if counts[msg] != want {
+			t.Errorf("Expected %d %q entries, got %d", want, msg, counts[msg])
+		}
+	}
+}
+
+// Test SampleRules to verify an invalid pattern is rejected
+func TestSampleRules_InvalidPattern(t *testing.T) {
+	_, err := NewLogger(&Config{SampleRules: []SampleRule{{MessagePattern: "("}}})
+	if err == nil {
+		t.Error("Expected an error for an invalid message pattern")
+	}
+}
+
+// Test SampleRules to verify a level without a zap counterpart is rejected instead of panicking
+func TestSampleRules_InvalidLevel(t *testing.T) {
+	_, err := NewLogger(&Config{SampleRules: []SampleRule{{MessagePattern: "x", LevelAtMost: PanicLevel}}})
+	if err == nil || !strings.Contains(err.Error(), `"x"`) {
+		t.Errorf("Expected an error naming the rule, got %v", err)
+	}
+}
+
+// Test SampleRules to verify TraceLevel is rejected instead of silently sampling debug entries
+func TestSampleRules_TraceLevel(t *testing.T) {
+	_, err := NewLogger(&Config{SampleRules: []SampleRule{{MessagePattern: "x", LevelAtMost: TraceLevel}}})
+	if err == nil || !strings.Contains(err.Error(), `"x"`) {
+		t.Errorf("Expected an error naming the rule, got %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package log
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+// scopedLoggers maps goroutine IDs to the logger installed by RunWith.
+var scopedLoggers sync.Map
+
+// RunWith runs fn with l installed as the logger returned by Current on the calling goroutine.
+// The previous scoped logger is restored when fn returns. Goroutines started by fn do not
+// inherit the scope and should receive the logger explicitly or through a context.
+func RunWith(l Logger, fn func()) {
+	id := goroutineID()
+	prev, nested := scopedLoggers.Load(id)
+	scopedLoggers.Store(id, l)
+	defer func() {
+		if nested {
+			scopedLoggers.Store(id, prev)
+		} else {
+			scopedLoggers.Delete(id)
+		}
+	}()
+	fn()
+}
+
+// Current returns the logger installed by RunWith for the calling goroutine,
+// falling back to FromDefaultContext outside of any scope.
+func Current() Logger {
+	if l, ok := scopedLoggers.Load(goroutineID()); ok {
+		return l.(Logger)
+	}
+	return FromDefaultContext()
+}
+
+// goroutineID extracts the current goroutine ID from the "goroutine N [status]:" stack header.
+func goroutineID() uint64 {
+	var buf [64]byte
+	n := runtime.Stack(buf[:], false)
+	fields := bytes.Fields(buf[:n])
+	if len(fields) < 2 {
+		return 0
+	}
+	id, _ := strconv.ParseUint(string(fields[1]), 10, 64)
+	return id
+}
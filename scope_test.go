@@ -0,0 +1,47 @@
+package log
+
+import (
+	"sync"
+	"testing"
+)
+
+// Test RunWith to verify concurrent scopes do not see each other's loggers
+func TestRunWith_Concurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			logger := newZapSome().WithField("scope", i)
+			RunWith(logger, func() {
+				for j := 0; j < 100; j++ {
+					if Current() != logger {
+						t.Errorf("Scope %d observed a foreign logger", i)
+						return
+					}
+				}
+			})
+			if Current() == logger {
+				t.Errorf("Scope %d logger leaked after RunWith returned", i)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Test RunWith to verify nested scopes restore the outer logger
+func TestRunWith_Nested(t *testing.T) {
+	outer := newZapSome().WithField("scope", "outer")
+	inner := newZapSome().WithField("scope", "inner")
+
+	RunWith(outer, func() {
+		RunWith(inner, func() {
+			if Current() != inner {
+				t.Error("Expected inner logger inside nested scope")
+			}
+		})
+		if Current() != outer {
+			t.Error("Expected outer logger restored after nested scope")
+		}
+	})
+}
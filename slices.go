@@ -0,0 +1,109 @@
+package log
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// SliceFormat selects how slice and array fields are rendered in console output.
+type SliceFormat string
+
+const (
+	// SliceArray renders slices as JSON arrays such as ["a", "b"]. It is the default.
+	SliceArray SliceFormat = "array"
+	// SliceJoined renders slices as a comma-joined string such as "a,b".
+	SliceJoined SliceFormat = "joined"
+)
+
+// joinedSliceEncoder wraps a console encoder and renders slice fields as comma-joined strings,
+// both for context fields added through With and for the fields of each entry.
+type joinedSliceEncoder struct {
+	zapcore.Encoder
+}
+
+// Clone keeps the slice rendering on encoders cloned for derived loggers.
+func (e joinedSliceEncoder) Clone() zapcore.Encoder {
+	return joinedSliceEncoder{e.Encoder.Clone()}
+}
+
+// AddArray adds arr as a comma-joined string.
+func (e joinedSliceEncoder) AddArray(key string, arr zapcore.ArrayMarshaler) error {
+	joined, err := joinArray(arr)
+	if err != nil {
+		return err
+	}
+	e.Encoder.AddString(key, joined)
+	return nil
+}
+
+// AddReflected adds slices and arrays as comma-joined strings and other values unchanged.
+func (e joinedSliceEncoder) AddReflected(key string, obj interface{}) error {
+	if joined, ok := joinReflected(obj); ok {
+		e.Encoder.AddString(key, joined)
+		return nil
+	}
+	return e.Encoder.AddReflected(key, obj)
+}
+
+// EncodeEntry renders the slice fields of the entry as strings before encoding it.
+func (e joinedSliceEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	var out []zapcore.Field
+	for i, f := range fields {
+		var joined string
+		switch f.Type {
+		case zapcore.ArrayMarshalerType:
+			var err error
+			if joined, err = joinArray(f.Interface.(zapcore.ArrayMarshaler)); err != nil {
+				continue
+			}
+		case zapcore.ReflectType:
+			var ok bool
+			if joined, ok = joinReflected(f.Interface); !ok {
+				continue
+			}
+		default:
+			continue
+		}
+		if out == nil {
+			out = append([]zapcore.Field(nil), fields...)
+		}
+		out[i] = zap.String(f.Key, joined)
+	}
+	if out == nil {
+		out = fields
+	}
+	return e.Encoder.EncodeEntry(ent, out)
+}
+
+// joinArray collects the elements of arr and joins them with commas.
+func joinArray(arr zapcore.ArrayMarshaler) (string, error) {
+	enc := zapcore.NewMapObjectEncoder()
+	if err := enc.AddArray("", arr); err != nil {
+		return "", err
+	}
+	elems, _ := enc.Fields[""].([]interface{})
+	return joinElements(len(elems), func(i int) interface{} { return elems[i] }), nil
+}
+
+// joinReflected joins the elements of obj with commas, reporting false if it is not a slice or array.
+func joinReflected(obj interface{}) (string, bool) {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return "", false
+	}
+	return joinElements(v.Len(), func(i int) interface{} { return v.Index(i).Interface() }), true
+}
+
+// joinElements formats n elements returned by at and joins them with commas.
+func joinElements(n int, at func(int) interface{}) string {
+	parts := make([]string, n)
+	for i := range parts {
+		parts[i] = fmt.Sprint(at(i))
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,36 @@
+package log
+
+import (
+	"strings"
+	"testing"
+)
+
+// Test ConsoleSlices to verify typed and reflected string slices render the same comma-joined way
+func TestConsoleSlices_Joined(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{DisableColor: true, ConsoleSlices: SliceJoined}, InfoLevel)
+	logger.WithField("tags", []string{"a", "b", "c"}).Info("context")
+	logger.Infow("entry", "tags", []interface{}{"a", "b", "c"}, "ids", []int{1, 2})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %q", buf.String())
+	}
+	for _, line := range lines {
+		if !strings.Contains(line, `"tags": "a,b,c"`) {
+			t.Errorf("Expected joined tags, got %q", line)
+		}
+	}
+	if !strings.Contains(lines[1], `"ids": "1,2"`) {
+		t.Errorf("Expected joined ids, got %q", lines[1])
+	}
+}
+
+// Test ConsoleSlices to verify the default keeps array rendering
+func TestConsoleSlices_Default(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{DisableColor: true}, InfoLevel)
+	logger.WithField("tags", []string{"a", "b"}).Info("context")
+
+	if !strings.Contains(buf.String(), `"tags": ["a", "b"]`) {
+		t.Errorf("Expected an array, got %q", buf.String())
+	}
+}
@@ -0,0 +1,56 @@
+package log
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// sortedMap is a zapcore.ObjectMarshaler writing the entries of a map in key order.
+type sortedMap struct {
+	m reflect.Value
+}
+
+// MarshalLogObject writes the entries sorted by their formatted keys. Nested maps are sorted as well.
+func (s sortedMap) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	keys := make([]string, 0, s.m.Len())
+	values := make(map[string]reflect.Value, s.m.Len())
+	iter := s.m.MapRange()
+	for iter.Next() {
+		k := fmt.Sprint(iter.Key().Interface())
+		keys = append(keys, k)
+		values[k] = iter.Value()
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		v := values[k]
+		for v.Kind() == reflect.Interface && !v.IsNil() {
+			v = v.Elem()
+		}
+		var err error
+		if v.Kind() == reflect.Map && !v.IsNil() {
+			err = enc.AddObject(k, sortedMap{v})
+		} else {
+			err = enc.AddReflected(k, v.Interface())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WithSortedMap attaches the map m under key with its entries in key order, so the same map always
+// renders identically. Keys are formatted with fmt.Sprint and nested maps are sorted too. Values that
+// are not maps are attached as is.
+func (l *zapLogger) WithSortedMap(key string, m interface{}) Logger {
+	rv := reflect.ValueOf(m)
+	if rv.Kind() != reflect.Map || rv.IsNil() {
+		return l.withFields(key, m)
+	}
+	return l.withFields(zap.Object(key, sortedMap{rv}))
+}
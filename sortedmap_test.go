@@ -0,0 +1,30 @@
+package log
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// Test WithSortedMap to verify the same map logs identically with keys in order, in JSON and console output
+func TestWithSortedMap(t *testing.T) {
+	m := map[string]interface{}{"zeta": 1, "alpha": 2, "mid": map[int]string{10: "b", 2: "a"}}
+	clock := func() time.Time { return time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC) }
+	for _, conf := range []*Config{{IsJson: true, Clock: clock}, {DisableColor: true, Clock: clock}} {
+		var outputs []string
+		for i := 0; i < 5; i++ {
+			logger, buf := newBufferedZap(t, conf, InfoLevel)
+			logger.WithSortedMap("counts", m).Info("stats")
+			outputs = append(outputs, buf.String())
+		}
+		for _, out := range outputs[1:] {
+			if out != outputs[0] {
+				t.Fatalf("Expected identical output, got %q and %q", outputs[0], out)
+			}
+		}
+		if !strings.Contains(outputs[0], `{"alpha": 2, "mid": {"10": "b", "2": "a"}, "zeta": 1}`) &&
+			!strings.Contains(outputs[0], `{"alpha":2,"mid":{"10":"b","2":"a"},"zeta":1}`) {
+			t.Errorf("Expected sorted keys, got %q", outputs[0])
+		}
+	}
+}
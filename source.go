@@ -0,0 +1,50 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// sourceContextLines is the number of lines shown before and after the caller line.
+const sourceContextLines = 2
+
+// addSourceSnippet attaches a "source" field with the code around the caller to Error and above entries.
+// Entries whose source file cannot be read are written unchanged.
+func addSourceSnippet(ent zapcore.Entry, fields []zapcore.Field) (zapcore.Entry, []zapcore.Field) {
+	if ent.Level < zapcore.ErrorLevel || !ent.Caller.Defined {
+		return ent, fields
+	}
+	if snippet, ok := sourceSnippet(ent.Caller.File, ent.Caller.Line); ok {
+		fields = appendFields(fields, zap.String("source", snippet))
+	}
+	return ent, fields
+}
+
+// sourceSnippet returns the lines surrounding line in file, marking the line itself with ">".
+func sourceSnippet(file string, line int) (string, bool) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", false
+	}
+	lines := strings.Split(string(data), "\n")
+	if line < 1 || line > len(lines) {
+		return "", false
+	}
+
+	from := max(line-sourceContextLines, 1)
+	to := min(line+sourceContextLines, len(lines))
+
+	var b strings.Builder
+	for n := from; n <= to; n++ {
+		marker := " "
+		if n == line {
+			marker = ">"
+		}
+		fmt.Fprintf(&b, "%s%5d | %s\n", marker, n, lines[n-1])
+	}
+	return b.String(), true
+}
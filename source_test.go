@@ -0,0 +1,35 @@
+package log
+
+import (
+	"strings"
+	"testing"
+)
+
+// Test SourceSnippet to verify error entries carry the code around the caller
+func TestSourceSnippet(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true, Development: true, SourceSnippet: true}, InfoLevel)
+	logger.Error("snippet marker")
+
+	entry := decodeEntry(t, buf)
+	source, _ := entry["source"].(string)
+	if !strings.Contains(source, `logger.Error("snippet marker")`) {
+		t.Errorf("Expected source snippet to contain the caller line, got %q", source)
+	}
+}
+
+// Test SourceSnippet to verify it stays disabled outside development mode
+func TestSourceSnippet_Production(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true, SourceSnippet: true}, InfoLevel)
+	logger.Error("no snippet")
+
+	if _, ok := decodeEntry(t, buf)["source"]; ok {
+		t.Error("Expected no source field when Development is disabled")
+	}
+}
+
+// Test sourceSnippet to verify missing files are handled gracefully
+func TestSourceSnippet_MissingFile(t *testing.T) {
+	if _, ok := sourceSnippet("/nonexistent/file.go", 10); ok {
+		t.Error("Expected missing source file to yield no snippet")
+	}
+}
@@ -0,0 +1,54 @@
+package log
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// loggerErrorKey is the field carrying the description of malformed arguments in strict mode.
+const loggerErrorKey = "_logger_error"
+
+// sanitizePairs returns kvs without the arguments the sugared logger would reject, and an error
+// describing them: a trailing key without a value or a key that is not a string. zap.Field and
+// error values stand alone, as they do for the sugared logger.
+func sanitizePairs(kvs []interface{}) ([]interface{}, error) {
+	kept := make([]interface{}, 0, len(kvs))
+	var err error
+	for i := 0; i < len(kvs); {
+		switch kvs[i].(type) {
+		case zap.Field, error:
+			kept = append(kept, kvs[i])
+			i++
+			continue
+		}
+		if i == len(kvs)-1 {
+			if err == nil {
+				err = fmt.Errorf("key %v at position %d has no value", kvs[i], i)
+			}
+			break
+		}
+		if _, ok := kvs[i].(string); ok {
+			kept = append(kept, kvs[i], kvs[i+1])
+		} else if err == nil {
+			err = fmt.Errorf("key at position %d is %T, not a string", i, kvs[i])
+		}
+		i += 2
+	}
+	return kept, err
+}
+
+// strictPairs returns the arguments to log in place of kvs. With Config.StrictFields, malformed
+// arguments are dropped and reported in a Warn entry carrying a "_logger_error" field instead of
+// the sugared logger's generic error. depth is the number of package frames above strictPairs,
+// so the Warn entry reports the user's call.
+func (l *zapLogger) strictPairs(kvs []interface{}, depth int) []interface{} {
+	if !l.strict {
+		return kvs
+	}
+	kept, err := sanitizePairs(kvs)
+	if err != nil {
+		l.log.WithOptions(zap.AddCallerSkip(1+depth)).Warnw("malformed key-value arguments", loggerErrorKey, err.Error())
+	}
+	return kept
+}
@@ -0,0 +1,51 @@
+package log
+
+import (
+	"strings"
+	"testing"
+)
+
+// Test StrictFields to verify an odd argument count is reported in a Warn entry at the caller
+func TestStrictFields_OddCount(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true, StrictFields: true}, InfoLevel)
+	logger.Infow("request", "path", "/", "status")
+
+	entries := decodeEntries(t, buf.String())
+	if len(entries) != 2 {
+		t.Fatalf("Expected a warning and the entry, got %s", buf.String())
+	}
+	warning, entry := entries[0], entries[1]
+	if warning["severity"] != "warn" || !strings.Contains(warning[loggerErrorKey].(string), "status") {
+		t.Errorf("Expected a Warn entry naming the dangling key, got %v", warning)
+	}
+	if !strings.Contains(warning["module"].(string), "strict_test.go") {
+		t.Errorf("Expected the warning to report the test as caller, got %v", warning["module"])
+	}
+	if entry["message"] != "request" || entry["path"] != "/" || strings.Contains(buf.String(), "Ignored key") {
+		t.Errorf("Expected the entry with the valid pairs only, got %v", entry)
+	}
+}
+
+// Test StrictFields to verify non-string keys passed to With are reported and dropped
+func TestStrictFields_With(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true, StrictFields: true}, InfoLevel)
+	logger.With(42, "answer", "user", "u-1").Info("done")
+
+	entries := decodeEntries(t, buf.String())
+	if len(entries) != 2 || !strings.Contains(entries[0][loggerErrorKey].(string), "int") {
+		t.Fatalf("Expected a warning about the int key, got %s", buf.String())
+	}
+	if entries[1]["user"] != "u-1" {
+		t.Errorf("Expected the valid pair on the entry, got %v", entries[1])
+	}
+}
+
+// Test StrictFields to verify malformed arguments keep the default handling when disabled
+func TestStrictFields_Disabled(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	logger.Infow("request", "status")
+
+	if strings.Contains(buf.String(), loggerErrorKey) {
+		t.Errorf("Expected no strict warning, got %s", buf.String())
+	}
+}
@@ -0,0 +1,86 @@
+package log
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// opaqueTypes are the interfaces of structs that render themselves; WithStruct logs them as values.
+var opaqueTypes = []reflect.Type{
+	reflect.TypeOf((*fmt.Stringer)(nil)).Elem(),
+	reflect.TypeOf((*json.Marshaler)(nil)).Elem(),
+	reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem(),
+}
+
+// WithStruct flattens the exported fields of the struct v into logger context as "prefix.field" pairs.
+// Field names follow json tags when present; fields tagged `json:"-"` are skipped, zero values of
+// fields tagged with omitempty are left out and nested structs are flattened recursively.
+// Unexported fields are never logged. Values that are not structs, and structs such as time.Time
+// that implement fmt.Stringer, json.Marshaler or encoding.TextMarshaler, are attached as is.
+func (l *zapLogger) WithStruct(prefix string, v interface{}) Logger {
+	return l.With(flattenStruct(prefix, v)...)
+}
+
+// flattenStruct converts v into alternating key-value pairs following the WithStruct rules.
+func flattenStruct(prefix string, v interface{}) []interface{} {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return []interface{}{prefix, nil}
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct || isOpaque(rv.Type()) {
+		return []interface{}{prefix, v}
+	}
+
+	var kvs []interface{}
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		value := rv.Field(i)
+		if strings.Contains(opts, "omitempty") && value.IsZero() {
+			continue
+		}
+
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+		if value.Kind() == reflect.Struct && !isOpaque(value.Type()) {
+			kvs = append(kvs, flattenStruct(key, value.Interface())...)
+			continue
+		}
+		kvs = append(kvs, key, value.Interface())
+	}
+	return kvs
+}
+
+// isOpaque reports whether values of the struct type t, or pointers to them, render themselves.
+func isOpaque(t reflect.Type) bool {
+	if t == reflect.TypeOf(time.Time{}) {
+		return true
+	}
+	for _, it := range opaqueTypes {
+		if t.Implements(it) || reflect.PointerTo(t).Implements(it) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package log
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// Test WithStruct to verify a two-field struct is flattened into prefixed fields
+func TestZapLogger_WithStruct(t *testing.T) {
+	type event struct {
+		Name    string `json:"name"`
+		Retries int
+		secret  string
+	}
+
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	logger.WithStruct("event", event{Name: "sync", Retries: 3, secret: "hidden"}).Info("flattened")
+
+	entry := decodeEntry(t, buf)
+	if entry["event.name"] != "sync" {
+		t.Errorf("Expected event.name=sync, got %v", entry["event.name"])
+	}
+	if entry["event.Retries"] != float64(3) {
+		t.Errorf("Expected event.Retries=3, got %v", entry["event.Retries"])
+	}
+	if _, ok := entry["event.secret"]; ok {
+		t.Error("Expected unexported field to be skipped")
+	}
+}
+
+// Test flattenStruct to verify omitempty, skipped and nested fields
+func TestFlattenStruct(t *testing.T) {
+	type inner struct {
+		Zone string `json:"zone"`
+	}
+	type config struct {
+		Region string `json:"region,omitempty"`
+		Token  string `json:"-"`
+		Inner  inner  `json:"inner"`
+	}
+
+	kvs := flattenStruct("cfg", &config{Token: "t", Inner: inner{Zone: "a"}})
+	if len(kvs) != 2 || kvs[0] != "cfg.inner.zone" || kvs[1] != "a" {
+		t.Errorf("Expected only the nested zone field, got %v", kvs)
+	}
+}
+
+// Test WithStruct to verify time.Time and other self-rendering structs are logged as values
+func TestZapLogger_WithStructOpaque(t *testing.T) {
+	type job struct {
+		Started time.Time `json:"started"`
+		Timeout duration  `json:"timeout"`
+	}
+	started := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	logger.WithStruct("job", job{Started: started, Timeout: duration{90}}).Info("scheduled")
+
+	entry := decodeEntry(t, buf)
+	if entry["job.started"] != "2024-05-01T12:00:00.000Z" {
+		t.Errorf("Expected the start time as a value, got %v", entry)
+	}
+	if entry["job.timeout"] != "90s" {
+		t.Errorf("Expected the Stringer value, got %v", entry)
+	}
+}
+
+// duration is a struct rendering itself through String
+type duration struct{ seconds int }
+
+func (d duration) String() string { return fmt.Sprintf("%ds", d.seconds) }
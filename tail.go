@@ -0,0 +1,218 @@
+package log
+
+import (
+	"bytes"
+	"net/http"
+	"sync"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// tailSubscriberBuffer is the number of lines buffered per tail client; a client that falls
+// further behind misses lines rather than growing memory.
+const tailSubscriberBuffer = 64
+
+// Tail keeps the most recent log lines in a bounded buffer and streams new ones to HTTP clients.
+// It is a zapcore.WriteSyncer, so it can be attached with WithTail or TeeDefault.
+type Tail struct {
+	mu      sync.Mutex
+	lines   [][]byte // Ring buffer of recent lines.
+	next    int      // Index of the slot the next line is written to.
+	full    bool     // Whether the ring buffer has wrapped around.
+	clients map[chan []byte]struct{}
+}
+
+// NewTail returns a Tail keeping the last size lines as backlog.
+func NewTail(size int) *Tail {
+	if size < 1 {
+		size = 1
+	}
+	return &Tail{lines: make([][]byte, size), clients: map[chan []byte]struct{}{}}
+}
+
+// WithTail returns a logger that also writes every entry it logs to t as JSON. Only entries the
+// logger actually writes reach t: entries removed by its level, sampling, rate limit or filters
+// do not. Lines carry the Config base fields and the context fields of base as well as those added
+// later. Loggers not built by this package are returned unchanged.
+func WithTail(base Logger, t *Tail) Logger {
+	l, ok := base.(*zapLogger)
+	if !ok {
+		return base
+	}
+	enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+		MessageKey:     "message",
+		LevelKey:       "severity",
+		TimeKey:        "timestamp",
+		CallerKey:      "module",
+		NameKey:        "logger",
+		EncodeLevel:    lowercaseTraceLevelEncoder,
+		EncodeTime:     zapcore.ISO8601TimeEncoder,
+		EncodeCaller:   zapcore.ShortCallerEncoder,
+		EncodeDuration: zapcore.StringDurationEncoder,
+		EncodeName:     separatorNameEncoder("."),
+	})
+	wrap := func(fields []zap.Field) zap.Option {
+		return zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			tap := zapcore.NewCore(enc, t, core).With(l.base)
+			return &tailCore{Core: core, tap: tap.With(fields)}
+		})
+	}
+	c := *l
+	c.setLog(l.log.WithOptions(wrap(contextFields(l.fields))))
+	if l.root != nil {
+		c.root = l.root.WithOptions(wrap(nil))
+	}
+	return &c
+}
+
+// contextFields converts the sugared context arguments kvs into zap.Fields. Malformed arguments,
+// reported when they were attached, are left out.
+func contextFields(kvs []interface{}) []zap.Field {
+	var fields []zap.Field
+	for _, item := range splitFields(kvs) {
+		switch {
+		case len(item.args) == 2:
+			fields = append(fields, zap.Any(item.key, item.args[1]))
+		case item.key != "":
+			fields = append(fields, item.args[0].(zap.Field))
+		}
+	}
+	return fields
+}
+
+// tailCore wraps a zapcore.Core and copies the entries it accepts to tap.
+type tailCore struct {
+	zapcore.Core
+	tap zapcore.Core
+}
+
+// With adds the context fields to both the wrapped core and the tap.
+func (c *tailCore) With(fields []zapcore.Field) zapcore.Core {
+	return &tailCore{Core: c.Core.With(fields), tap: c.tap.With(fields)}
+}
+
+// Check adds the tap to the entry checked by the wrapped core, keeping the hooks it set. Below a
+// tee, where the wrapped core's acceptance cannot be told apart, the tail core registers itself.
+func (c *tailCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if ce != nil {
+		return checkWrapped(c.Core, c, ent, ce)
+	}
+	checked := c.Core.Check(ent, nil)
+	if checked == nil {
+		return nil
+	}
+	return checked.AddCore(ent, c.tap)
+}
+
+// Write writes the entry to the wrapped core and the tap.
+func (c *tailCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return multierr.Append(c.Core.Write(ent, fields), c.tap.Write(ent, fields))
+}
+
+// Sync flushes the wrapped core and the tap.
+func (c *tailCore) Sync() error {
+	return multierr.Append(c.Core.Sync(), c.tap.Sync())
+}
+
+// Write records a line, trimming the trailing newline, and sends it to the connected clients.
+func (t *Tail) Write(p []byte) (int, error) {
+	line := bytes.TrimRight(append([]byte(nil), p...), "\n")
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lines[t.next] = line
+	t.next = (t.next + 1) % len(t.lines)
+	if t.next == 0 {
+		t.full = true
+	}
+	for c := range t.clients {
+		select {
+		case c <- line:
+		default: // The client is too slow; drop the line for it.
+		}
+	}
+	return len(p), nil
+}
+
+// Sync does nothing; lines are kept in memory.
+func (t *Tail) Sync() error {
+	return nil
+}
+
+// Backlog returns the buffered lines, oldest first.
+func (t *Tail) Backlog() [][]byte {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.backlog()
+}
+
+// backlog returns the buffered lines, oldest first. The caller must hold t.mu.
+func (t *Tail) backlog() [][]byte {
+	if !t.full {
+		return append([][]byte(nil), t.lines[:t.next]...)
+	}
+	return append(append([][]byte(nil), t.lines[t.next:]...), t.lines[:t.next]...)
+}
+
+// subscribe registers a client channel, optionally returning the backlog atomically with it.
+func (t *Tail) subscribe(backlog bool) (chan []byte, [][]byte) {
+	c := make(chan []byte, tailSubscriberBuffer)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.clients[c] = struct{}{}
+	if backlog {
+		return c, t.backlog()
+	}
+	return c, nil
+}
+
+// unsubscribe removes a client channel.
+func (t *Tail) unsubscribe(c chan []byte) {
+	t.mu.Lock()
+	delete(t.clients, c)
+	t.mu.Unlock()
+}
+
+// TailHandler returns a handler streaming new lines as Server-Sent Events, one "data:" event per
+// line, until the client disconnects. With the query parameter backlog=1 the buffered lines are
+// sent first. Mount it on a debug-only listener, e.g. mux.Handle("/logs/tail", tail.TailHandler()).
+func (t *Tail) TailHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		c, backlog := t.subscribe(r.URL.Query().Get("backlog") == "1")
+		defer t.unsubscribe(c)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusOK)
+		for _, line := range backlog {
+			writeEvent(w, line)
+		}
+		flusher.Flush()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case line := <-c:
+				if err := writeEvent(w, line); err != nil {
+					return
+				}
+				flusher.Flush()
+			}
+		}
+	})
+}
+
+// writeEvent writes line as a single Server-Sent Event.
+func writeEvent(w http.ResponseWriter, line []byte) error {
+	_, err := w.Write(append(append([]byte("data: "), line...), '\n', '\n'))
+	return err
+}
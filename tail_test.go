@@ -0,0 +1,110 @@
+package log
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Test TailHandler to verify a connected client receives a line logged after it connected
+func TestTail_TailHandler(t *testing.T) {
+	tail := NewTail(10)
+	base, _ := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	logger := WithTail(base, tail)
+	logger.Info("before connect")
+
+	srv := httptest.NewServer(tail.TailHandler())
+	defer srv.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", srv.URL+"?backlog=1", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Expected an event stream, got %q", ct)
+	}
+
+	logger.Info("after connect")
+	reader := bufio.NewReader(resp.Body)
+	var events []string
+	for len(events) < 2 {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Reading the stream failed after %v: %v", events, err)
+		}
+		if strings.HasPrefix(line, "data: ") {
+			events = append(events, line)
+		}
+	}
+	if !strings.Contains(events[0], "before connect") || !strings.Contains(events[1], "after connect") {
+		t.Errorf("Expected the backlog then the new line, got %q", events)
+	}
+}
+
+// Test Tail to verify the backlog is bounded and keeps the newest lines
+func TestTail_Backlog(t *testing.T) {
+	tail := NewTail(2)
+	for _, line := range []string{"a\n", "b\n", "c\n"} {
+		tail.Write([]byte(line))
+	}
+	backlog := tail.Backlog()
+	if len(backlog) != 2 || string(backlog[0]) != "b" || string(backlog[1]) != "c" {
+		t.Errorf("Expected the last two lines, got %q", backlog)
+	}
+}
+
+// Test WithTail to verify only the entries the logger writes reach the tail, with their context fields
+func TestWithTail_Written(t *testing.T) {
+	tail := NewTail(10)
+	conf := &Config{IsJson: true, SampleRules: []SampleRule{{LevelAtMost: InfoLevel, MessagePattern: "^noise$", KeepEveryN: 100}}}
+	base, buf := newBufferedZap(t, conf, InfoLevel)
+	logger := WithTail(base, tail).WithField("request_id", "r-1")
+
+	for i := 0; i < 3; i++ {
+		logger.Info("noise")
+	}
+	logger.Debug("disabled")
+	logger.Warn("kept")
+
+	backlog := tail.Backlog()
+	if len(backlog) != 2 || strings.Count(buf.String(), "\n") != 2 {
+		t.Fatalf("Expected the 2 written entries in the tail, got %q and output %q", backlog, buf.String())
+	}
+	if !strings.Contains(string(backlog[1]), "kept") || !strings.Contains(string(backlog[1]), `"request_id":"r-1"`) {
+		t.Errorf("Expected the written entry with its context field, got %q", backlog[1])
+	}
+}
+
+// Test WithTail to verify the tail carries the base fields and the context fields added before WithTail
+func TestWithTail_EarlierFields(t *testing.T) {
+	tail := NewTail(10)
+	base, buf := newBufferedZap(t, &Config{IsJson: true, IncludePID: true}, InfoLevel)
+	logger := WithTail(base.WithField("tenant", "acme"), tail).WithField("request_id", "r-1")
+
+	logger.Info("served")
+	logger.Reset().Info("reset")
+
+	backlog := tail.Backlog()
+	if len(backlog) != 2 {
+		t.Fatalf("Expected 2 lines in the tail, got %q", backlog)
+	}
+	written := decodeEntries(t, buf.String())
+	tailed := decodeEntries(t, string(backlog[0])+"\n"+string(backlog[1])+"\n")
+	for i := range written {
+		for _, key := range []string{"pid", "tenant", "request_id"} {
+			if written[i][key] != tailed[i][key] {
+				t.Errorf("Expected %s to match the output, got %v and %v", key, written[i], tailed[i])
+			}
+		}
+	}
+	if tailed[0]["tenant"] != "acme" || tailed[0]["pid"] == nil || tailed[1]["tenant"] != nil {
+		t.Errorf("Expected the earlier and base fields, dropped by Reset, got %q", backlog)
+	}
+}
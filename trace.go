@@ -0,0 +1,51 @@
+package log
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// WithTraceID attaches id as the "trace_id" field.
+func (l *zapLogger) WithTraceID(id string) Logger {
+	c := l.withFields(traceIDKey, id)
+	c.traceID = id
+	return c
+}
+
+// ContextWithTraceID returns a copy of ctx carrying id as the trace id.
+func ContextWithTraceID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, traceIDKey, id)
+}
+
+// TraceIDFromContext returns the trace id stored in ctx, or "" if there is none.
+func TraceIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(traceIDKey).(string)
+	return id
+}
+
+// EnsureTraceID returns ctx and its trace id, generating and storing a new id if ctx has none.
+func EnsureTraceID(ctx context.Context) (context.Context, string) {
+	if id := TraceIDFromContext(ctx); id != "" {
+		return ctx, id
+	}
+	id := newTraceID()
+	return ContextWithTraceID(ctx, id), id
+}
+
+// NewRequestLogger derives a logger for r from l with the standard request fields and the trace id
+// of the request context, generated if missing. It returns the logger and a copy of r whose context
+// carries both, so FromContext(r.Context()) and downstream calls share the same trace id.
+func NewRequestLogger(l Logger, r *http.Request) (Logger, *http.Request) {
+	ctx, id := EnsureTraceID(r.Context())
+	rl := l.WithRequestFields(r).WithTraceID(id)
+	return rl, r.WithContext(ToContext(ctx, rl))
+}
+
+// newTraceID returns a random 128-bit id in hex, the size of a W3C trace id.
+func newTraceID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,72 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"testing"
+)
+
+// Test EnsureTraceID to verify a generated id is stable across FromContext calls on the same context
+func TestEnsureTraceID(t *testing.T) {
+	t.Cleanup(SaveState())
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	SetContextFallback(logger)
+
+	ctx, id := EnsureTraceID(context.Background())
+	if len(id) != 32 {
+		t.Fatalf("Expected a 32 character id, got %q", id)
+	}
+	if again, sameID := EnsureTraceID(ctx); again != ctx || sameID != id {
+		t.Errorf("Expected the existing id to be kept, got %q", sameID)
+	}
+
+	FromContext(ctx).Info("first")
+	first := decodeEntry(t, buf)
+	buf.Reset()
+	FromContext(ctx).Info("second")
+	second := decodeEntry(t, buf)
+	if first["trace_id"] != id || second["trace_id"] != id {
+		t.Errorf("Expected trace_id %q on both entries, got %v and %v", id, first["trace_id"], second["trace_id"])
+	}
+}
+
+// Test NewRequestLogger to verify the request context carries the logger and its trace id
+func TestNewRequestLogger(t *testing.T) {
+	base, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	rl, r := NewRequestLogger(base, httptest.NewRequest("GET", "/health", nil))
+
+	id := TraceIDFromContext(r.Context())
+	if id == "" {
+		t.Fatal("Expected a trace id in the request context")
+	}
+	FromContext(r.Context()).Info("checked")
+	if entry := decodeEntry(t, buf); entry["trace_id"] != id || entry["path"] != "/health" {
+		t.Errorf("Expected trace_id and request fields, got %v", entry)
+	}
+	if FromContext(r.Context()) != rl {
+		t.Error("Expected FromContext to return the request logger")
+	}
+	if bytes.Count(buf.Bytes(), []byte("trace_id")) != 1 {
+		t.Errorf("Expected a single trace_id field, got %q", buf.String())
+	}
+}
+
+// Test that a logger stored with ToContext receives the trace id of the context from FromContext and the Context methods
+func TestTraceIDFromContext_StoredLogger(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	ctx := ContextWithTraceID(ToContext(context.Background(), logger), "4bf92f3577b34da6a3ce929d0e0e4736")
+
+	FromContext(ctx).Info("from context")
+	logger.InfoContext(ctx, "info context")
+	logger.WithTraceID("4bf92f3577b34da6a3ce929d0e0e4736").WithContext(ctx).Info("already attached")
+
+	for _, entry := range decodeEntries(t, buf.String()) {
+		if entry["trace_id"] != "4bf92f3577b34da6a3ce929d0e0e4736" {
+			t.Errorf("Expected the trace id of the context, got %v", entry)
+		}
+	}
+	if n := bytes.Count(buf.Bytes(), []byte("trace_id")); n != 3 {
+		t.Errorf("Expected one trace_id field per entry, got %d in %q", n, buf.String())
+	}
+}
@@ -0,0 +1,94 @@
+package log
+
+import (
+	"encoding/base64"
+	"fmt"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// truncateCore wraps a zapcore.Core and shortens string and byte field values longer than limit bytes.
+// Unlike transformCore it also rewrites the context fields passed to With, so values attached through
+// WithField are covered as well as those passed to Infow and friends.
+type truncateCore struct {
+	zapcore.Core
+	limit int
+}
+
+// With truncates the context fields before handing them to the wrapped core.
+func (c *truncateCore) With(fields []zapcore.Field) zapcore.Core {
+	return &truncateCore{Core: c.Core.With(truncateFields(fields, c.limit)), limit: c.limit}
+}
+
+// Check registers the truncating core for entries the wrapped core would accept.
+func (c *truncateCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return checkWrapped(c.Core, c, ent, ce)
+}
+
+// Write truncates the entry fields and passes them to the wrapped core.
+func (c *truncateCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(ent, truncateFields(fields, c.limit))
+}
+
+// truncateFields returns fields with oversized values shortened. The caller's slice is copied only
+// when at least one field needs truncating.
+func truncateFields(fields []zapcore.Field, limit int) []zapcore.Field {
+	var out []zapcore.Field
+	for i, f := range fields {
+		t, ok := truncateField(f, limit)
+		if !ok {
+			continue
+		}
+		if out == nil {
+			out = make([]zapcore.Field, len(fields))
+			copy(out, fields)
+		}
+		out[i] = t
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
+// truncateField shortens a string or byte field longer than limit bytes, appending a marker
+// with the number of bytes removed. Binary fields become string fields holding the base64 of the
+// kept bytes followed by the marker, so the marker stays readable. It reports false if the field
+// was left as is.
+func truncateField(f zapcore.Field, limit int) (zapcore.Field, bool) {
+	switch f.Type {
+	case zapcore.StringType:
+		if len(f.String) <= limit {
+			return f, false
+		}
+		f.String = truncateString(f.String, limit)
+	case zapcore.ByteStringType, zapcore.BinaryType:
+		b, ok := f.Interface.([]byte)
+		if !ok || len(b) <= limit {
+			return f, false
+		}
+		if f.Type == zapcore.BinaryType {
+			return zap.String(f.Key, truncatedMarker([]byte(base64.StdEncoding.EncodeToString(b[:limit])), len(b)-limit)), true
+		}
+		f.Interface = []byte(truncatedMarker(b[:limit], len(b)-limit))
+	default:
+		return f, false
+	}
+	return f, true
+}
+
+// truncateString cuts s to at most limit bytes without splitting a UTF-8 sequence.
+func truncateString(s string, limit int) string {
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return truncatedMarker([]byte(s[:cut]), len(s)-cut)
+}
+
+// truncatedMarker appends the "…(truncated N bytes)" marker to the kept prefix.
+func truncatedMarker(kept []byte, removed int) string {
+	return fmt.Sprintf("%s…(truncated %d bytes)", kept, removed)
+}
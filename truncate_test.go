@@ -0,0 +1,45 @@
+package log
+
+import (
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Test MaxFieldBytes to verify oversized context and entry fields are truncated while short ones are untouched
+func TestMaxFieldBytes(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true, MaxFieldBytes: 8}, InfoLevel)
+	logger.WithField("payload", strings.Repeat("x", 20)).Infow("call", "short", "ok")
+
+	entry := decodeEntry(t, buf)
+	if want := "xxxxxxxx…(truncated 12 bytes)"; entry["payload"] != want {
+		t.Errorf("Expected payload %q, got %v", want, entry["payload"])
+	}
+	if entry["short"] != "ok" {
+		t.Errorf("Expected short value to be untouched, got %v", entry["short"])
+	}
+}
+
+// Test truncateField to verify byte slices are truncated and UTF-8 sequences are not split
+func TestTruncateField(t *testing.T) {
+	f, ok := truncateField(zap.ByteString("data", []byte("0123456789")), 4)
+	if !ok || string(f.Interface.([]byte)) != "0123…(truncated 6 bytes)" {
+		t.Errorf("Expected truncated bytes, got %v", f.Interface)
+	}
+
+	f, ok = truncateField(zap.Binary("blob", []byte{0xff, 0xfe, 0xfd, 0xfc}), 3)
+	if !ok || f.Type != zapcore.StringType || f.String != "//79…(truncated 1 bytes)" {
+		t.Errorf("Expected a string field with the base64 prefix and a readable marker, got %v %q", f.Type, f.String)
+	}
+
+	f, ok = truncateField(zap.String("text", "aéé"), 2)
+	if !ok || f.String != "a…(truncated 4 bytes)" {
+		t.Errorf("Expected cut on a rune boundary, got %q", f.String)
+	}
+
+	if _, ok = truncateField(zap.Int("n", 1), 0); ok {
+		t.Error("Expected non-string fields to be left as is")
+	}
+}
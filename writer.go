@@ -0,0 +1,60 @@
+package log
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// logWriter is an io.WriteCloser that logs each newline-terminated line as a separate entry.
+type logWriter struct {
+	mu    sync.Mutex
+	log   *zap.SugaredLogger
+	level zapcore.Level
+	buf   []byte // Pending partial line awaiting its newline.
+}
+
+// WriteCloser returns an io.WriteCloser logging every written line at level.
+// Partial writes are buffered until a newline arrives; Close logs any remainder and flushes the logger.
+func (l *zapLogger) WriteCloser(level LogLevel) io.WriteCloser {
+	lvl := zapcore.InfoLevel
+	if converted := convLevel(level); converted != nil {
+		lvl = *converted
+	}
+	return &logWriter{
+		log:   l.log.WithOptions(zap.WithCaller(false)).With(callerClampField(false)),
+		level: lvl,
+	}
+}
+
+// Write buffers p and logs every complete line it contains.
+func (w *logWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.log.Log(w.level, string(w.buf[:i]))
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Close logs any buffered partial line and flushes the underlying logger.
+func (w *logWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) > 0 {
+		w.log.Log(w.level, string(w.buf))
+		w.buf = nil
+	}
+	return w.log.Sync()
+}
@@ -0,0 +1,58 @@
+package log
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+// Test WriteCloser to verify partial writes are joined into a single entry
+func TestZapLogger_WriteCloser(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	w := logger.WriteCloser(WarnLevel)
+
+	io.WriteString(w, "a")
+	if buf.Len() != 0 {
+		t.Fatalf("Expected partial line to be buffered, got %q", buf.String())
+	}
+	io.WriteString(w, "b\n")
+
+	entry := decodeEntry(t, buf)
+	if entry["message"] != "ab" || entry["severity"] != "warn" {
+		t.Errorf("Expected one warn entry with message %q, got %v", "ab", entry)
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != 1 {
+		t.Errorf("Expected exactly one entry, got %d", lines)
+	}
+}
+
+// Test WriteCloser to verify Close logs the remaining partial line
+func TestZapLogger_WriteCloserClose(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	w := logger.WriteCloser(InfoLevel)
+
+	io.WriteString(w, "tail")
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if msg := decodeEntry(t, buf)["message"]; msg != "tail" {
+		t.Errorf("Expected Close to log %q, got %v", "tail", msg)
+	}
+}
+
+// Test WriteCloser to verify its lines have no caller and are not clamped, also on a logger skipping callers
+func TestZapLogger_WriteCloserNoCaller(t *testing.T) {
+	errs := recordInternalErrors(t)
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+
+	for _, l := range []Logger{logger, logger.SkipCallers(1)} {
+		io.WriteString(l.WriteCloser(InfoLevel), "line\n")
+		if entry := decodeEntry(t, buf); entry["module"] != nil {
+			t.Errorf("Expected no caller, got %v", entry)
+		}
+		buf.Reset()
+	}
+	if got := errs(); len(got) != 0 {
+		t.Errorf("Expected no internal errors, got %v", got)
+	}
+}
@@ -1,9 +1,17 @@
 package log
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"os"
 	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -11,8 +19,26 @@ import (
 
 // zapLogger is a struct that encapsulates zap's SugaredLogger and custom trace level handling.
 type zapLogger struct {
-	log        zap.SugaredLogger // The main logger instance for logging.
-	traceLevel bool              // Indicates if trace-level logging is enabled.
+	log        zap.SugaredLogger            // The main logger instance for logging.
+	traceLevel bool                         // Indicates if trace-level logging is enabled.
+	configured bool                         // Indicates if the logger was built from a Config rather than as a fallback.
+	levels     *levelState                  // Shared level and enabled-level table; nil for the unconfigured fallback.
+	sink       *switchSink                  // Shared output destination; nil for the unconfigured fallback.
+	dev        bool                         // Indicates if development mode is enabled, making DPanic panic.
+	root       *zap.SugaredLogger           // Counterpart of log without the accumulated context fields.
+	fields     []interface{}                // Context fields accumulated through With and related methods.
+	ctxKeys    []string                     // Context keys copied into fields by WithContext.
+	baggage    int                          // Maximum number of OpenTelemetry baggage members copied by WithContext.
+	files      []*fileSink                  // File outputs, reopened by Reopen.
+	plain      *desugaredCache              // Non-sugared counterpart of log used by the w-variants; reset by setLog.
+	buffered   *zapcore.BufferedWriteSyncer // Buffered output when Config.FlushIntervalMs is set.
+	async      *AsyncWriter                 // Asynchronous output when Config.AsyncBufferSize is set.
+	strict     bool                         // Reports malformed key-value arguments, set by Config.StrictFields.
+	required   []string                     // Context keys WithContext attaches and warns about when missing.
+	callerSkip int                          // Frames added through SkipCallers, applied by trace like zap applies them.
+	traceID    string                       // Trace id attached as a field, so WithContext does not attach it again.
+	escapeDots bool                         // Named escapes dots in segments for the Config.NameSeparator encoder.
+	base       []zap.Field                  // Config base fields, replayed on cores built apart from the logger's own.
 }
 
 // skipCallers defines the number of stack frames to skip when retrieving caller information.
@@ -20,17 +46,16 @@ var skipCallers = 1
 
 // options defines global zap options to set up the logger's behavior, such as caller information.
 var options = []zap.Option{
-	zap.Development(),
 	zap.AddCaller(),
 	zap.AddCallerSkip(skipCallers),
 }
 
-// convLevel converts a custom LogLevel to a corresponding zapcore.Level.
+// convLevel converts a custom LogLevel to a corresponding zapcore.Level; UnsetLevel converts like InfoLevel.
 // Returns nil if the LogLevel is invalid.
 func convLevel(level LogLevel) *zapcore.Level {
 	var lvl zapcore.Level
 
-	switch level {
+	switch resolveLevel(level) {
 	case TraceLevel:
 		lvl = zap.DebugLevel
 	case DebugLevel:
@@ -50,50 +75,288 @@ func convLevel(level LogLevel) *zapcore.Level {
 	return &lvl
 }
 
+// fromZapLevel converts a zapcore.Level back to the corresponding LogLevel.
+func fromZapLevel(lvl zapcore.Level) LogLevel {
+	switch {
+	case lvl < zapcore.DebugLevel:
+		return TraceLevel
+	case lvl == zapcore.DebugLevel:
+		return DebugLevel
+	case lvl == zapcore.InfoLevel:
+		return InfoLevel
+	case lvl == zapcore.WarnLevel:
+		return WarnLevel
+	case lvl == zapcore.ErrorLevel:
+		return ErrorLevel
+	case lvl == zapcore.FatalLevel:
+		return FatalLevel
+	default:
+		return PanicLevel
+	}
+}
+
 // newZap creates a new zapLogger instance based on the provided configuration.
 // Accepts a boolean for JSON formatting and a LogLevel for severity.
 // Returns an error if the LogLevel is invalid.
 func newZap(json bool, level LogLevel) (Logger, error) {
-	lvl := convLevel(level)
+	return newZapFromConfig(&Config{IsJson: json}, level)
+}
 
-	if lvl == nil {
-		return nil, errors.New("wrong logging level")
+// newZapFromConfig creates a new zapLogger writing to the configured output paths according to conf.
+// The level is passed separately as conf.Level holds its textual form.
+func newZapFromConfig(conf *Config, level LogLevel) (Logger, error) {
+	if len(conf.Sinks) > 0 {
+		return newSinksLogger(conf, level)
+	}
+	sink, files, err := openOutputs(conf.OutputPaths)
+	if err != nil {
+		return nil, err
+	}
+	var buffered *zapcore.BufferedWriteSyncer
+	if conf.FlushIntervalMs > 0 {
+		buffered = &zapcore.BufferedWriteSyncer{WS: sink, FlushInterval: time.Duration(conf.FlushIntervalMs) * time.Millisecond}
+		sink = buffered
+	}
+	var async *AsyncWriter
+	if conf.AsyncBufferSize > 0 {
+		async = NewAsyncWriter(sink, conf.AsyncBufferSize, conf.Backpressure)
+		sink = async
+	}
+	l, err := buildZap(conf, level, sink)
+	if err != nil {
+		for _, f := range files {
+			_ = f.Close()
+		}
+		return nil, err
+	}
+	l.files = files
+	l.buffered = buffered
+	l.async = async
+	return l, nil
+}
+
+// buildZap assembles the encoder, core and options of a zapLogger writing to sink.
+func buildZap(conf *Config, level LogLevel, sink zapcore.WriteSyncer) (*zapLogger, error) {
+	levels, err := newLevelState(level)
+	if err != nil {
+		return nil, err
 	}
 
-	config := zap.Config{
-		Level:       zap.NewAtomicLevelAt(*lvl),
-		Development: true,
-		Encoding:    "json",
-		OutputPaths: []string{"stdout"},
-		EncoderConfig: zapcore.EncoderConfig{
-			MessageKey:   "message",
-			LevelKey:     "severity",
-			TimeKey:      "timestamp",
-			CallerKey:    "module",
-			EncodeLevel:  zapcore.LowercaseLevelEncoder,
-			EncodeTime:   zapcore.ISO8601TimeEncoder,
-			EncodeCaller: zapcore.ShortCallerEncoder,
-		},
+	encoderConfig := zapcore.EncoderConfig{
+		MessageKey:     "message",
+		LevelKey:       "severity",
+		TimeKey:        "timestamp",
+		CallerKey:      "module",
+		NameKey:        "logger",
+		EncodeLevel:    zapcore.LowercaseLevelEncoder,
+		EncodeTime:     zapcore.ISO8601TimeEncoder,
+		EncodeCaller:   zapcore.ShortCallerEncoder,
+		EncodeDuration: durationEncoder(conf.DurationEncoder),
 	}
 
+	// The "ecs" preset implies JSON, while "plain" is console output without color but with timestamps.
+	plain := conf.Encoding == EncodingPlain
+	isJSON := (conf.IsJson || conf.Encoding == EncodingECS || conf.Encoding == EncodingEMF || conf.Encoding == EncodingGCP) && !plain
+	noColor := conf.DisableColor || plain
+
 	// Configure logger for console output if JSON formatting is disabled.
-	if !json {
-		config.Encoding = "console"
-		config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
-		config.EncoderConfig.TimeKey = ""
-		config.EncoderConfig.EncodeCaller = bracketsCallerEncoder
+	if !isJSON {
+		encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+		if noColor {
+			encoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+		}
+		if !plain {
+			encoderConfig.TimeKey = ""
+		}
+		encoderConfig.EncodeCaller = bracketsCallerEncoder
+	}
+
+	if conf.NameSeparator != "" {
+		encoderConfig.EncodeName = separatorNameEncoder(conf.NameSeparator)
+	}
+
+	if conf.CallerTrimPrefix != "" || conf.CallerMode != "" {
+		encoderConfig.EncodeCaller = callerEncoder(conf.CallerTrimPrefix, conf.CallerMode, !isJSON)
 	}
 
 	// Custom handling for TraceLevel logs.
 	if level == TraceLevel {
-		config.EncoderConfig.EncodeLevel = TraceLevelEncoder
+		switch {
+		case isJSON:
+			encoderConfig.EncodeLevel = lowercaseTraceLevelEncoder
+		case noColor:
+			encoderConfig.EncodeLevel = plainTraceLevelEncoder
+		default:
+			encoderConfig.EncodeLevel = TraceLevelEncoder
+		}
 	}
 
-	logger, err := config.Build()
-	if err != nil {
-		return nil, err
+	if !isJSON && !noColor && len(conf.LevelColors) > 0 {
+		encoderConfig.EncodeLevel = colorLevelEncoder(conf.LevelColors, encoderConfig.EncodeLevel)
+	}
+
+	if !isJSON && conf.LevelToken {
+		encoderConfig.EncodeLevel = levelTokenEncoder(encoderConfig.EncodeLevel)
+	}
+
+	if conf.Encoding == EncodingECS {
+		encoderConfig = ecsEncoderConfig(encoderConfig)
+	}
+	if conf.Encoding == EncodingGCP {
+		encoderConfig = gcpEncoderConfig(encoderConfig)
+	}
+
+	var encoder zapcore.Encoder
+	if isJSON {
+		encoder = zapcore.NewJSONEncoder(encoderConfig)
+	} else {
+		encoder = zapcore.NewConsoleEncoder(encoderConfig)
+		if conf.ConsoleSlices == SliceJoined {
+			encoder = joinedSliceEncoder{encoder}
+		}
+	}
+	encoder = sealingEncoder{encoder}
+
+	out := newSwitchSink(sink)
+	var core zapcore.Core = zapcore.NewCore(encoder, out, levels.atom)
+	if conf.EventLogSource != "" {
+		core, err = newEventLogCore(conf.EventLogSource, encoder, levels.atom)
+		if err != nil {
+			return nil, err
+		}
+	}
+	core = &internalErrorCore{core}
+	if conf.Development && conf.SourceSnippet {
+		core = newTransformCore(core, addSourceSnippet)
+	}
+	if conf.PanicStacktrace {
+		core = newTransformCore(core, addPanicStacktrace)
+	}
+	if conf.StacktraceMaxFrames > 0 {
+		core = newTransformCore(core, stackLimiter(conf.StacktraceMaxFrames))
+	}
+	if conf.LevelNumber {
+		core = newTransformCore(core, addSeverityNumber)
 	}
-	return &zapLogger{*logger.Sugar(), TraceLevel == level}, nil
+	if conf.Encoding == EncodingECS {
+		core = newTransformCore(core, addECSOrigin)
+	}
+	if conf.Encoding == EncodingEMF {
+		core = newEMFCore(core, conf.MetricNamespace)
+	}
+	if conf.Encoding == EncodingGCP {
+		core = &gcpTraceCore{Core: core, project: conf.GCPProject}
+	}
+	if conf.GlobalSequence {
+		core = newTransformCore(core, addGlobalSequence)
+	}
+	if conf.IncludePackage {
+		core = newTransformCore(core, addCallerPackage)
+	}
+	// Outside the transforms above, so they see the clamped caller.
+	core = &clampCallerCore{Core: core}
+	if len(conf.SampleRules) > 0 {
+		rules, err := compileSampleRules(conf.SampleRules)
+		if err != nil {
+			return nil, err
+		}
+		core = &samplerCore{Core: core, rules: rules}
+	}
+	if conf.MaxFieldBytes > 0 {
+		core = &truncateCore{Core: core, limit: conf.MaxFieldBytes}
+	}
+	onFatal := fatalHook(conf)
+	if min := convLevel(conf.ExitOnLevel); conf.ExitOnLevel != UnsetLevel && min != nil && *min < zapcore.DPanicLevel {
+		core = &fatalLevelCore{Core: core, min: *min, hook: onFatal}
+	}
+	core = &rateLimitCore{&dropCore{core}}
+	base := baseFields(conf)
+	options := append(zapOptions(conf, core), zap.Fields(base...))
+	logger := zap.New(core, options...).Sugar()
+	return &zapLogger{
+		log:        *logger,
+		plain:      &desugaredCache{},
+		traceLevel: TraceLevel == level,
+		configured: true,
+		levels:     levels,
+		sink:       out,
+		dev:        conf.Development,
+		root:       logger,
+		ctxKeys:    append([]string(nil), conf.ContextKeys...),
+		baggage:    conf.BaggageMembers,
+		strict:     conf.StrictFields,
+		required:   append([]string(nil), conf.RequiredContextKeys...),
+		escapeDots: conf.NameSeparator != "",
+		base:       base,
+	}, nil
+}
+
+// zapOptions returns the zap options of a logger built from conf writing to core. The base fields
+// are left out, as the cores of Config.Sinks already carry them.
+func zapOptions(conf *Config, core zapcore.Core) []zap.Option {
+	options := []zap.Option{
+		zap.ErrorOutput(zapcore.AddSync(io.Discard)),
+		zap.AddCaller(),
+		zap.AddStacktrace(zap.WarnLevel),
+	}
+	// Panic entries are flushed before the panic unwinds, so they are not lost in buffered outputs.
+	options = append(options, zap.WithFatalHook(fatalHook(conf)), zap.WithPanicHook(syncThenPanic{core.Sync}))
+	if conf.Development {
+		options = append(options, zap.Development())
+	}
+	if conf.Clock != nil {
+		options = append(options, zap.WithClock(clockFunc(conf.Clock)))
+	}
+	return options
+}
+
+// hostnameOnce guards the one-time hostname lookup used by the host base field.
+var (
+	hostnameOnce  sync.Once
+	hostnameValue string
+)
+
+// hostname returns the machine hostname, resolved once per process.
+func hostname() string {
+	hostnameOnce.Do(func() {
+		h, err := os.Hostname()
+		if err != nil {
+			h = "unknown"
+		}
+		hostnameValue = h
+	})
+	return hostnameValue
+}
+
+// baseFields returns the fields attached to every entry written by a logger built from conf.
+func baseFields(conf *Config) []zap.Field {
+	var fields []zap.Field
+	if conf.IncludeHost {
+		fields = append(fields, zap.String("host", hostname()))
+	}
+	if conf.IncludePID {
+		fields = append(fields, zap.Int("pid", os.Getpid()))
+	}
+	if conf.IncludeBuildInfo {
+		fields = append(fields, buildInfoFields()...)
+	}
+	if conf.Encoding == EncodingECS {
+		fields = append(fields, zap.String("ecs.version", ecsVersion))
+	}
+	return fields
+}
+
+// clockFunc adapts a func() time.Time to zapcore.Clock, used to timestamp entries.
+type clockFunc func() time.Time
+
+// Now returns the time reported by the function.
+func (c clockFunc) Now() time.Time {
+	return c()
+}
+
+// NewTicker returns a real ticker; only entry timestamps follow the function.
+func (c clockFunc) NewTicker(d time.Duration) *time.Ticker {
+	return time.NewTicker(d)
 }
 
 // TraceLevelEncoder formats trace-level messages distinctly for higher visibility.
@@ -105,6 +368,74 @@ func TraceLevelEncoder(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
 	zapcore.CapitalColorLevelEncoder(l, enc)
 }
 
+// nameDot stands for a "." inside a name segment when Config.NameSeparator is set, so the name
+// encoder can tell it apart from the "." zap joins segments with.
+const nameDot = "\x1f"
+
+// separatorNameEncoder renders nested logger names with their segments joined by sep instead of
+// zap's ".", keeping the dots within segments.
+func separatorNameEncoder(sep string) zapcore.NameEncoder {
+	return func(name string, enc zapcore.PrimitiveArrayEncoder) {
+		enc.AppendString(strings.ReplaceAll(strings.ReplaceAll(name, ".", sep), nameDot, "."))
+	}
+}
+
+// plainTraceLevelEncoder is the uncolored variant of TraceLevelEncoder.
+func plainTraceLevelEncoder(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	if l == zapcore.DebugLevel-1 {
+		enc.AppendString("TRACE")
+		return
+	}
+	zapcore.CapitalLevelEncoder(l, enc)
+}
+
+// colorLevelEncoder renders the levels present in colors with the given ANSI SGR codes (e.g. "1;31"
+// for bold red), leaving a level uncolored when its code is empty. Other levels use fallback.
+func colorLevelEncoder(colors map[LogLevel]string, fallback zapcore.LevelEncoder) zapcore.LevelEncoder {
+	codes := make(map[LogLevel]string, len(colors))
+	for lvl, code := range colors {
+		codes[lvl] = code
+	}
+	return func(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+		code, ok := codes[fromZapLevel(l)]
+		if !ok {
+			fallback(l, enc)
+			return
+		}
+		label := l.CapitalString()
+		if l == zapcore.DebugLevel-1 {
+			label = "TRACE"
+		}
+		if code == "" {
+			enc.AppendString(label)
+			return
+		}
+		enc.AppendString("\x1b[" + code + "m" + label + "\x1b[0m")
+	}
+}
+
+// lowercaseTraceLevelEncoder is the JSON counterpart of TraceLevelEncoder, writing "trace" and lowercase levels.
+func lowercaseTraceLevelEncoder(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	if l == zapcore.DebugLevel-1 {
+		enc.AppendString("trace")
+		return
+	}
+	zapcore.LowercaseLevelEncoder(l, enc)
+}
+
+// levelTokenEncoder appends a machine-readable "level=<name>" token, such as "level=warn", after the
+// label written by inner, so console lines stay parseable when the label is colored.
+func levelTokenEncoder(inner zapcore.LevelEncoder) zapcore.LevelEncoder {
+	return func(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+		inner(l, enc)
+		name := l.String()
+		if l == zapcore.DebugLevel-1 {
+			name = "trace"
+		}
+		enc.AppendString("level=" + name)
+	}
+}
+
 // bracketsCallerEncoder formats the caller path within brackets for enhanced readability.
 func bracketsCallerEncoder(caller zapcore.EntryCaller, enc zapcore.PrimitiveArrayEncoder) {
 	enc.AppendString("[" + caller.TrimmedPath() + "]:")
@@ -116,20 +447,32 @@ func newZapSome() *zapLogger {
 	config.EncoderConfig.StacktraceKey = ""
 	config.EncoderConfig.TimeKey = ""
 	l, _ := config.Build()
-	return &zapLogger{*l.Named("<unconfigured logger>").Sugar(), false}
+	logger := l.Named("<unconfigured logger>").Sugar()
+	return &zapLogger{log: *logger, dev: true, root: logger, plain: &desugaredCache{}}
+}
+
+// newZapNop initializes a zapLogger that discards all entries.
+func newZapNop() *zapLogger {
+	logger := zap.NewNop().Sugar()
+	return &zapLogger{log: *logger, configured: true, root: logger, plain: &desugaredCache{}}
 }
 
-// trace logs a custom trace-level message, with adjustments for caller information.
-func trace(l *zapLogger, msg string) {
+// trace logs a custom trace-level message with fields, with adjustments for caller information. It
+// must be called directly from the method called by the user; frames added through SkipCallers are
+// skipped on top, as zap does for the other levels.
+func trace(l *zapLogger, msg string, fields ...zap.Field) {
 	skipLogger := l.log.WithOptions(options...)
 	const callerSkipOffset = 2
+	if !allowTrace() {
+		return
+	}
 	ce := &zapcore.CheckedEntry{}
 	ce = ce.AddCore(zapcore.Entry{}, skipLogger.Desugar().Core())
 	if ce != nil {
-		ce.Entry.Caller = zapcore.NewEntryCaller(runtime.Caller(callerSkipOffset))
+		ce.Entry.Caller = zapcore.NewEntryCaller(runtime.Caller(callerSkipOffset + l.callerSkip))
 		ce.Entry.Message = msg
 		ce.Entry.Level = zapcore.DebugLevel - 1
-		ce.Write()
+		ce.Write(fields...)
 	}
 }
 
@@ -140,13 +483,23 @@ func (l *zapLogger) Info(i ...interface{}) {
 }
 
 func (l *zapLogger) Infof(s string, i ...interface{}) {
+	if !l.enabled(zapcore.InfoLevel) {
+		return
+	}
 	skipLogger := l.log.WithOptions(options...)
 	skipLogger.Infof(s, i...)
 }
 
 func (l *zapLogger) Infow(s string, i ...interface{}) {
+	l.logw(zapcore.InfoLevel, s, i)
+}
+
+// Infom logs the formatted message at Info and returns it.
+func (l *zapLogger) Infom(format string, args ...interface{}) string {
+	msg := formatMessage(format, args)
 	skipLogger := l.log.WithOptions(options...)
-	skipLogger.Infow(s, i...)
+	skipLogger.Info(msg)
+	return msg
 }
 
 func (l *zapLogger) Warn(i ...interface{}) {
@@ -155,13 +508,28 @@ func (l *zapLogger) Warn(i ...interface{}) {
 }
 
 func (l *zapLogger) Warnf(s string, i ...interface{}) {
+	if !l.enabled(zapcore.WarnLevel) {
+		return
+	}
 	skipLogger := l.log.WithOptions(options...)
 	skipLogger.Warnf(s, i...)
 }
 
 func (l *zapLogger) Warnw(s string, i ...interface{}) {
+	l.logw(zapcore.WarnLevel, s, i)
+}
+
+// warnedKeys records the keys passed to WarnOnce by any logger of the process.
+var warnedKeys sync.Map
+
+// WarnOnce logs msg at Warn the first time key is passed by any logger of the process and ignores
+// later calls with the same key, e.g. for deprecation notices.
+func (l *zapLogger) WarnOnce(key, msg string) {
+	if _, seen := warnedKeys.LoadOrStore(key, struct{}{}); seen {
+		return
+	}
 	skipLogger := l.log.WithOptions(options...)
-	skipLogger.Warnw(s, i...)
+	skipLogger.Warn(msg)
 }
 
 func (l *zapLogger) Error(i ...interface{}) {
@@ -170,13 +538,60 @@ func (l *zapLogger) Error(i ...interface{}) {
 }
 
 func (l *zapLogger) Errorf(s string, i ...interface{}) {
+	if !l.enabled(zapcore.ErrorLevel) {
+		return
+	}
 	skipLogger := l.log.WithOptions(options...)
 	skipLogger.Errorf(s, i...)
 }
 
 func (l *zapLogger) Errorw(s string, i ...interface{}) {
+	l.logw(zapcore.ErrorLevel, s, i)
+}
+
+// Errorm logs the formatted message at Error and returns it.
+func (l *zapLogger) Errorm(format string, args ...interface{}) string {
+	msg := formatMessage(format, args)
 	skipLogger := l.log.WithOptions(options...)
-	skipLogger.Errorw(s, i...)
+	skipLogger.Error(msg)
+	return msg
+}
+
+// InfoMap logs msg at Info with the entries of fields, in key order, after the context fields.
+func (l *zapLogger) InfoMap(msg string, fields map[string]interface{}) {
+	l.logw(zapcore.InfoLevel, msg, mapPairs(fields))
+}
+
+// WarnMap logs msg at Warn with the entries of fields, in key order, after the context fields.
+func (l *zapLogger) WarnMap(msg string, fields map[string]interface{}) {
+	l.logw(zapcore.WarnLevel, msg, mapPairs(fields))
+}
+
+// ErrorMap logs msg at Error with the entries of fields, in key order, after the context fields.
+func (l *zapLogger) ErrorMap(msg string, fields map[string]interface{}) {
+	l.logw(zapcore.ErrorLevel, msg, mapPairs(fields))
+}
+
+// mapPairs flattens fields into key-value pairs sorted by key, so output is deterministic.
+func mapPairs(fields map[string]interface{}) []interface{} {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	kvs := make([]interface{}, 0, 2*len(keys))
+	for _, k := range keys {
+		kvs = append(kvs, k, fields[k])
+	}
+	return kvs
+}
+
+// formatMessage formats like the f-variants: a format without arguments is used verbatim.
+func formatMessage(format string, args []interface{}) string {
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
 }
 
 func (l *zapLogger) Debug(i ...interface{}) {
@@ -185,23 +600,29 @@ func (l *zapLogger) Debug(i ...interface{}) {
 }
 
 func (l *zapLogger) Debugf(s string, i ...interface{}) {
+	if !l.enabled(zapcore.DebugLevel) {
+		return
+	}
 	skipLogger := l.log.WithOptions(options...)
 	skipLogger.Debugf(s, i...)
 }
 
+// Trace and Tracef emit at the custom trace sub-level, so their lines are labeled TRACE
+// and can be told apart from Debug output when the logger runs at TraceLevel.
 func (l *zapLogger) Trace(s string, i ...interface{}) {
-	skipLogger := l.log.WithOptions(options...)
-	skipLogger.Debugf(s, i...)
+	if l.Check(TraceLevel) {
+		trace(l, fmt.Sprintf(s, i...))
+	}
 }
 
 func (l *zapLogger) Tracef(s string, i ...interface{}) {
-	skipLogger := l.log.WithOptions(options...)
-	skipLogger.Debugf(s, i...)
+	if l.Check(TraceLevel) {
+		trace(l, fmt.Sprintf(s, i...))
+	}
 }
 
 func (l *zapLogger) Debugw(s string, i ...interface{}) {
-	skipLogger := l.log.WithOptions(options...)
-	skipLogger.Debugw(s, i...)
+	l.logw(zapcore.DebugLevel, s, i)
 }
 
 func (l *zapLogger) Fatal(i ...interface{}) {
@@ -224,36 +645,317 @@ func (l *zapLogger) Printf(arg string, int ...interface{}) {
 
 func (l *zapLogger) Panic(args ...interface{}) {
 	skipLogger := l.log.WithOptions(options...)
-	skipLogger.Panic(args)
+	skipLogger.Panic(args...)
 }
 
 func (l *zapLogger) Panicf(msg string, args ...interface{}) {
 	skipLogger := l.log.WithOptions(options...)
-	skipLogger.Panicf(msg, args)
+	skipLogger.Panicf(msg, args...)
+}
+
+// withFields returns a copy of the logger with kvs added to its context fields.
+func (l *zapLogger) withFields(kvs ...interface{}) *zapLogger {
+	c := *l
+	c.setLog(l.log.With(kvs...))
+	c.fields = append(l.fields[:len(l.fields):len(l.fields)], kvs...)
+	return &c
+}
+
+// withOptions returns a copy of the logger with opts applied, keeping its context fields.
+func (l *zapLogger) withOptions(opts ...zap.Option) *zapLogger {
+	c := *l
+	c.setLog(l.log.WithOptions(opts...))
+	if l.root != nil {
+		c.root = l.root.WithOptions(opts...)
+	}
+	return &c
+}
+
+// wrapCore returns a copy of the logger whose core is wrapped by fn.
+func (l *zapLogger) wrapCore(fn func(zapcore.Core) zapcore.Core) *zapLogger {
+	return l.withOptions(zap.WrapCore(fn))
+}
+
+// ZapUnwrapper is implemented by loggers backed by zap, such as those built by NewLogger, giving
+// access to the underlying *zap.Logger for libraries that expect one.
+type ZapUnwrapper interface {
+	// Unwrap returns the underlying *zap.Logger with the accumulated context fields.
+	Unwrap() *zap.Logger
+}
+
+// Unwrap returns the underlying *zap.Logger, carrying the context fields, name and output of l.
+// Trace entries and the other additions of Logger are not available through it.
+func (l *zapLogger) Unwrap() *zap.Logger {
+	return l.log.Desugar()
+}
+
+// IsConfigured reports whether the logger was built from a Config rather than being the unconfigured fallback.
+func (l *zapLogger) IsConfigured() bool {
+	return l.configured
+}
+
+// DPanic logs at DPanic level and panics in development mode; otherwise it logs at Error level.
+func (l *zapLogger) DPanic(args ...interface{}) {
+	skipLogger := l.log.WithOptions(options...)
+	if l.dev {
+		skipLogger.DPanic(args...)
+		return
+	}
+	skipLogger.Error(args...)
+}
+
+// DPanicf is the formatted variant of DPanic.
+func (l *zapLogger) DPanicf(msg string, args ...interface{}) {
+	skipLogger := l.log.WithOptions(options...)
+	if l.dev {
+		skipLogger.DPanicf(msg, args...)
+		return
+	}
+	skipLogger.Errorf(msg, args...)
 }
 
 // WithError attaches an error message as a context field to the logger.
 func (l *zapLogger) WithError(err error) Logger {
-	return &zapLogger{*l.log.With("error", err), l.traceLevel}
+	return l.withFields("error", err)
 }
 
 // WithField attaches a key-value pair as a context field to the logger.
 func (l *zapLogger) WithField(key string, value interface{}) Logger {
-	return &zapLogger{*l.log.With(key, value), l.traceLevel}
+	return l.withFields(key, value)
+}
+
+// WithCodedError attaches err as an "error" field and, when err or an error it wraps has a
+// Code() string method, its code as an "error_code" field.
+func (l *zapLogger) WithCodedError(err error) Logger {
+	var coded interface{ Code() string }
+	if errors.As(err, &coded) {
+		return l.withFields("error", err, "error_code", coded.Code())
+	}
+	return l.withFields("error", err)
+}
+
+// WithErrorChain attaches err as an "error" field, its Go type as "error_type" and the messages of
+// err and every error it wraps, outermost first, as "error_chain". The chain follows single
+// wrapping through errors.Unwrap and ends at errors joining several others. A nil err is ignored.
+func (l *zapLogger) WithErrorChain(err error) Logger {
+	if err == nil {
+		return l
+	}
+	var chain []string
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		chain = append(chain, e.Error())
+	}
+	return l.withFields("error", err, "error_type", fmt.Sprintf("%T", err), "error_chain", chain)
+}
+
+// WithFieldIf adds the key-value pair only when cond is true and returns the logger unchanged otherwise.
+func (l *zapLogger) WithFieldIf(cond bool, key string, value interface{}) Logger {
+	if !cond {
+		return l
+	}
+	return l.withFields(key, value)
+}
+
+// WithContext copies the values stored in ctx under the configured ContextKeys and
+// RequiredContextKeys into fields named after the keys. Keys without a value are skipped, and
+// missing required keys are reported in a Warn entry. The trace id stored with ContextWithTraceID
+// is attached as "trace_id" unless the logger already carries it. With Config.BaggageMembers set,
+// OpenTelemetry baggage members are attached as well, up to that many.
+func (l *zapLogger) WithContext(ctx context.Context) Logger {
+	return l.withContext(ctx)
+}
+
+// withContext implements WithContext, returning the concrete logger for the Context methods.
+// It must be called directly from the method called by the user, so the Warn entry about
+// missing required keys reports the right caller.
+func (l *zapLogger) withContext(ctx context.Context) *zapLogger {
+	var kvs []interface{}
+	for _, key := range l.ctxKeys {
+		if v := ctx.Value(key); v != nil {
+			kvs = append(kvs, key, v)
+		}
+	}
+	var missing []string
+	for _, key := range l.required {
+		if v := ctx.Value(key); v != nil {
+			kvs = append(kvs, key, v)
+		} else {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		l.log.WithOptions(zap.AddCallerSkip(2)).Warnw("required context keys missing",
+			loggerErrorKey, "missing required context keys: "+strings.Join(missing, ", "))
+	}
+	if l.baggage > 0 {
+		kvs = appendBaggage(kvs, ctx, l.baggage)
+	}
+	id := TraceIDFromContext(ctx)
+	if id != "" && id != l.traceID {
+		kvs = append(kvs, traceIDKey, id)
+	}
+	if len(kvs) == 0 {
+		return l
+	}
+	c := l.withFields(kvs...)
+	if id != "" {
+		c.traceID = id
+	}
+	return c
+}
+
+// DebugContext logs msg at Debug with the key-value pairs and the context fields of ctx, as
+// WithContext(ctx).Debugw(msg, kvs...) would. Disabled levels return before ctx is read, so they
+// neither clone the logger nor warn about missing required context keys.
+func (l *zapLogger) DebugContext(ctx context.Context, msg string, kvs ...interface{}) {
+	if !l.enabled(zapcore.DebugLevel) {
+		return
+	}
+	l.withContext(ctx).logw(zapcore.DebugLevel, msg, kvs)
+}
+
+// InfoContext logs msg at Info with the key-value pairs and the context fields of ctx.
+func (l *zapLogger) InfoContext(ctx context.Context, msg string, kvs ...interface{}) {
+	if !l.enabled(zapcore.InfoLevel) {
+		return
+	}
+	l.withContext(ctx).logw(zapcore.InfoLevel, msg, kvs)
+}
+
+// WarnContext logs msg at Warn with the key-value pairs and the context fields of ctx.
+func (l *zapLogger) WarnContext(ctx context.Context, msg string, kvs ...interface{}) {
+	if !l.enabled(zapcore.WarnLevel) {
+		return
+	}
+	l.withContext(ctx).logw(zapcore.WarnLevel, msg, kvs)
+}
+
+// ErrorContext logs msg at Error with the key-value pairs and the context fields of ctx.
+func (l *zapLogger) ErrorContext(ctx context.Context, msg string, kvs ...interface{}) {
+	if !l.enabled(zapcore.ErrorLevel) {
+		return
+	}
+	l.withContext(ctx).logw(zapcore.ErrorLevel, msg, kvs)
+}
+
+// Named adds a segment to the logger name; nested names are joined with Config.NameSeparator.
+func (l *zapLogger) Named(name string) Logger {
+	if l.escapeDots {
+		name = strings.ReplaceAll(name, ".", nameDot)
+	}
+	c := *l
+	c.setLog(l.log.Named(name))
+	if l.root != nil {
+		c.root = l.root.Named(name)
+	}
+	return &c
+}
+
+// WithObject attaches obj under key, serialized natively through its MarshalLogObject method.
+func (l *zapLogger) WithObject(key string, obj zapcore.ObjectMarshaler) Logger {
+	return l.withFields(zap.Object(key, obj))
+}
+
+// WithPrefix prepends prefix to the message of every entry; nested prefixes concatenate.
+func (l *zapLogger) WithPrefix(prefix string) Logger {
+	return l.wrapCore(func(core zapcore.Core) zapcore.Core {
+		return newTransformCore(core, func(ent zapcore.Entry, fields []zapcore.Field) (zapcore.Entry, []zapcore.Field) {
+			ent.Message = prefix + ent.Message
+			return ent, fields
+		})
+	})
 }
 
 // SkipCallers configures the logger to skip a specified number of caller stack frames.
+// When the count exceeds the stack at the time of logging, the entry reports the deepest frame
+// outside the runtime package instead, and the clamping is reported as an internal error.
 func (l *zapLogger) SkipCallers(count int) Logger {
-	return &zapLogger{*l.log.Desugar().WithOptions(zap.AddCallerSkip(count)).Sugar(), l.traceLevel}
+	c := l.withOptions(zap.AddCallerSkip(count))
+	c.callerSkip += count
+	if count > 0 {
+		clamp := callerClampField(true)
+		c.setLog(c.log.With(clamp))
+		if c.root != nil {
+			c.root = c.root.With(clamp)
+		}
+	}
+	return c
+}
+
+// CallerAt returns a logger whose next entry reports the caller skip frames further up the stack.
+// Only that single entry is affected; unlike SkipCallers, later entries use the regular caller.
+func (l *zapLogger) CallerAt(skip int) Logger {
+	var used atomic.Bool
+	return l.wrapCore(func(core zapcore.Core) zapcore.Core {
+		return newTransformCore(core, func(ent zapcore.Entry, fields []zapcore.Field) (zapcore.Entry, []zapcore.Field) {
+			if ent.Caller.Defined && used.CompareAndSwap(false, true) {
+				ent.Caller = callerAbove(ent.Caller, skip)
+			}
+			return ent, fields
+		})
+	})
 }
 
 // With adds multiple context fields for structured logging.
 func (l *zapLogger) With(f ...interface{}) Logger {
-	return &zapLogger{log: *l.log.With(f)}
+	return l.withFields(l.strictPairs(f, 1)...)
+}
+
+// WithValidated adds context fields like With, but requires alternating string keys and values
+// and returns an error instead of logging a malformed pair.
+func (l *zapLogger) WithValidated(kvs ...interface{}) (Logger, error) {
+	if err := validatePairs(kvs); err != nil {
+		return l, err
+	}
+	return l.withFields(kvs...), nil
+}
+
+// validatePairs checks that kvs holds alternating string keys and values.
+func validatePairs(kvs []interface{}) error {
+	if len(kvs)%2 != 0 {
+		return fmt.Errorf("odd number of key-value arguments: %d", len(kvs))
+	}
+	for i := 0; i < len(kvs); i += 2 {
+		if _, ok := kvs[i].(string); !ok {
+			return fmt.Errorf("key at position %d is %T, not a string", i, kvs[i])
+		}
+	}
+	return nil
+}
+
+// AtLevel returns a logger keeping the fields, name and output of l but with its own level, which
+// SetLevel on the result changes without affecting l. The level filters entries on top of the
+// levels of l, so the result cannot write entries l has disabled, and Check and Trace follow both.
+// An invalid level is reported as an internal error and l is returned.
+func (l *zapLogger) AtLevel(level LogLevel) Logger {
+	levels, err := newLevelState(level)
+	if err != nil {
+		reportInternalError(err)
+		return l
+	}
+	levels.parent = l.levels
+	c := l.wrapCore(func(core zapcore.Core) zapcore.Core {
+		return &levelGateCore{Core: core, min: levels}
+	})
+	c.levels = levels
+	c.traceLevel = l.Check(TraceLevel) && resolveLevel(level) == TraceLevel
+	return c
+}
+
+// SetLevel changes the minimum severity of the logger and all loggers derived from it.
+func (l *zapLogger) SetLevel(level LogLevel) error {
+	if l.levels == nil {
+		return errors.New("cannot change the level of an unconfigured logger")
+	}
+	return l.levels.set(level)
 }
 
 // Check determines if logging should proceed at the specified LogLevel.
 func (l *zapLogger) Check(level LogLevel) bool {
+	if l.levels != nil {
+		return l.levels.check(level)
+	}
+
 	if level == TraceLevel {
 		return l.traceLevel
 	}
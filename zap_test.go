@@ -1,9 +1,17 @@
 package log
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -58,6 +66,47 @@ func TestZapLogger_Check(t *testing.T) {
 	}
 }
 
+// Test SetLevel to verify Check follows level changes on the logger and its derived loggers
+func TestZapLogger_SetLevel(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	derived := logger.WithField("key", "value")
+
+	if err := logger.SetLevel(DebugLevel); err != nil {
+		t.Fatal(err)
+	}
+	if !derived.Check(DebugLevel) || derived.Check(TraceLevel) {
+		t.Error("Expected Check to report Debug enabled and Trace disabled after SetLevel(DebugLevel)")
+	}
+	derived.Debug("visible")
+	if !strings.Contains(buf.String(), "visible") {
+		t.Error("Expected debug entry after lowering the level")
+	}
+
+	if err := logger.SetLevel(ErrorLevel); err != nil {
+		t.Fatal(err)
+	}
+	if logger.Check(WarnLevel) || !logger.Check(ErrorLevel) {
+		t.Error("Expected Check to report Warn disabled and Error enabled after SetLevel(ErrorLevel)")
+	}
+
+	if err := logger.SetLevel(LogLevel(100)); err == nil {
+		t.Error("Expected error on invalid log level")
+	}
+}
+
+// Benchmark Check to verify the cached lookup does not allocate
+func BenchmarkZapLogger_Check(b *testing.B) {
+	logger, err := newZap(true, InfoLevel)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.Check(DebugLevel)
+	}
+}
+
 // Test WithError to verify if error is attached as a context field
 func TestZapLogger_WithError(t *testing.T) {
 	logger := newZapSome()
@@ -80,6 +129,52 @@ func TestZapLogger_WithField(t *testing.T) {
 	}
 }
 
+// user implements zapcore.ObjectMarshaler for WithObject tests
+type user struct {
+	ID   int
+	Name string
+}
+
+func (u user) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt("id", u.ID)
+	enc.AddString("name", u.Name)
+	return nil
+}
+
+// Test Named to verify nested names are joined with the configured separator
+func TestZapLogger_NamedSeparator(t *testing.T) {
+	tests := []struct {
+		separator string
+		want      string
+	}{
+		{"", "a.b.c.d"},
+		{"/", "a/b.c/d"},
+	}
+
+	for _, tt := range tests {
+		logger, buf := newBufferedZap(t, &Config{IsJson: true, NameSeparator: tt.separator}, InfoLevel)
+		logger.Named("a").Named("b.c").Named("d").Info("named")
+
+		if name := decodeEntry(t, buf)["logger"]; name != tt.want {
+			t.Errorf("Named with separator %q = %v; want %v", tt.separator, name, tt.want)
+		}
+	}
+}
+
+// Test WithObject to verify marshalers are serialized as nested JSON objects
+func TestZapLogger_WithObject(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	logger.WithObject("user", user{ID: 7, Name: "ann"}).Info("object")
+
+	obj, ok := decodeEntry(t, buf)["user"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected nested user object, got %s", buf.String())
+	}
+	if obj["id"] != float64(7) || obj["name"] != "ann" {
+		t.Errorf("Expected user object fields, got %v", obj)
+	}
+}
+
 // Test SkipCallers to ensure the correct number of stack frames are skipped
 func TestZapLogger_SkipCallers(t *testing.T) {
 	logger := newZapSome()
@@ -90,6 +185,79 @@ func TestZapLogger_SkipCallers(t *testing.T) {
 	}
 }
 
+// Test SkipCallers to verify an absurd skip is clamped to a meaningful frame and reported
+func TestZapLogger_SkipCallersClamped(t *testing.T) {
+	errs := recordInternalErrors(t)
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+
+	logger.SkipCallers(1000).Info("deep")
+
+	entry := decodeEntry(t, buf)
+	caller, _ := entry["module"].(string)
+	if caller == "" || caller == "undefined" || strings.HasPrefix(caller, "runtime/") {
+		t.Errorf("Expected a meaningful caller, got %q", caller)
+	}
+	if got := errs(); len(got) != 1 || !strings.Contains(got[0].Error(), "caller skip exceeds") {
+		t.Errorf("Expected the clamping to be reported, got %v", got)
+	}
+}
+
+// Test SkipCallers to verify the skip applies where the logger logs rather than where it was derived
+func TestZapLogger_SkipCallersDerivedShallow(t *testing.T) {
+	errs := recordInternalErrors(t)
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	derived := make(chan Logger)
+	go func() { derived <- logger.SkipCallers(2) }()
+	skipping := <-derived
+
+	wrapper := func() { skipping.Info("wrapped") }
+	outer := func() { wrapper() }
+	outer()
+	_, _, line, _ := runtime.Caller(0)
+
+	entry := decodeEntry(t, buf)
+	if want := fmt.Sprintf("zap_test.go:%d", line-1); !strings.HasSuffix(entry["module"].(string), want) {
+		t.Errorf("Expected the caller two frames up at %s, got %v", want, entry["module"])
+	}
+	if got := errs(); len(got) != 0 {
+		t.Errorf("Expected no internal errors, got %v", got)
+	}
+}
+
+// Test IsConfigured to distinguish the unconfigured fallback from configured loggers
+func TestZapLogger_IsConfigured(t *testing.T) {
+	if newZapSome().IsConfigured() {
+		t.Error("Expected unconfigured fallback logger to report false")
+	}
+
+	logger, err := newZap(true, InfoLevel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !logger.IsConfigured() {
+		t.Error("Expected configured logger to report true")
+	}
+	if !logger.WithField("key", "value").IsConfigured() {
+		t.Error("Expected derived logger to keep its configured state")
+	}
+}
+
+// Test WithPrefix to verify messages are prefixed and nested prefixes concatenate
+func TestZapLogger_WithPrefix(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+
+	logger.WithPrefix("[x] ").Info("hi")
+	if msg := decodeEntry(t, buf)["message"]; msg != "[x] hi" {
+		t.Errorf("Expected prefixed message %q, got %q", "[x] hi", msg)
+	}
+
+	buf.Reset()
+	logger.WithPrefix("[a] ").WithPrefix("[b] ").Infof("n=%d", 1)
+	if msg := decodeEntry(t, buf)["message"]; msg != "[a] [b] n=1" {
+		t.Errorf("Expected nested prefixes %q, got %q", "[a] [b] n=1", msg)
+	}
+}
+
 // Test TraceLevelEncoder for formatting trace level messages
 func TestTraceLevelEncoder(t *testing.T) {
 	encoder := zapcore.NewConsoleEncoder(zapcore.EncoderConfig{
@@ -111,6 +279,68 @@ func TestTraceLevelEncoder(t *testing.T) {
 	}
 }
 
+// Test DisableColor to verify no escape sequences appear in console output
+func TestDisableColor(t *testing.T) {
+	for _, level := range []LogLevel{InfoLevel, TraceLevel} {
+		logger, buf := newBufferedZap(t, &Config{DisableColor: true}, level)
+		logger.Info("plain")
+		logger.Error("plain")
+		logger.Print("plain")
+
+		if strings.Contains(buf.String(), "\x1b[") {
+			t.Errorf("Expected no ANSI escapes at level %v, got %q", level, buf.String())
+		}
+		if !strings.Contains(buf.String(), "ERROR") {
+			t.Errorf("Expected uncolored level label at level %v, got %q", level, buf.String())
+		}
+	}
+}
+
+// Test that at TraceLevel debug and trace methods produce distinctly labeled lines
+func TestZapLogger_TraceAndDebugLabels(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{DisableColor: true}, TraceLevel)
+	logger.Debug("debug line")
+	logger.Tracef("trace %s", "line")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected two lines, got %q", buf.String())
+	}
+	if !strings.Contains(lines[0], "DEBUG") || !strings.Contains(lines[0], "debug line") {
+		t.Errorf("Expected DEBUG labeled debug line, got %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "TRACE") || !strings.Contains(lines[1], "trace line") {
+		t.Errorf("Expected TRACE labeled trace line, got %q", lines[1])
+	}
+
+	debugOnly, debugBuf := newBufferedZap(t, &Config{DisableColor: true}, DebugLevel)
+	debugOnly.Trace("hidden")
+	if debugBuf.Len() != 0 {
+		t.Errorf("Expected no trace output at DebugLevel, got %q", debugBuf.String())
+	}
+}
+
+// Test that trace lines report the caller like other levels, including frames added by SkipCallers
+func TestZapLogger_TraceCaller(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, TraceLevel)
+	logger.Print("printed")
+	logger.Trace("traced")
+	helper := func() { logger.SkipCallers(1).(*zapLogger).Tracef("skipped") }
+	helper()
+	_, _, line, _ := runtime.Caller(0)
+
+	entries := decodeEntries(t, buf.String())
+	for _, entry := range entries {
+		module, _ := entry["module"].(string)
+		if !strings.Contains(module, "zap_test.go") {
+			t.Errorf("Expected %q to report the test file as caller, got %q", entry["message"], module)
+		}
+	}
+	if want := fmt.Sprintf("zap_test.go:%d", line-1); !strings.HasSuffix(entries[2]["module"].(string), want) {
+		t.Errorf("Expected the skipped trace line at %s, got %v", want, entries[2]["module"])
+	}
+}
+
 // Test bracketsCallerEncoder to validate the formatting of caller information within brackets
 func TestBracketsCallerEncoder(t *testing.T) {
 	encoder := zapcore.NewConsoleEncoder(zapcore.EncoderConfig{
@@ -132,7 +362,396 @@ func TestBracketsCallerEncoder(t *testing.T) {
 	}
 }
 
+// Test that host and pid base fields are attached when enabled in Config
+func TestZapLogger_HostAndPID(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true, IncludeHost: true, IncludePID: true}, InfoLevel)
+	logger.Info("hello")
+
+	entry := decodeEntry(t, buf)
+	if host, _ := entry["host"].(string); host == "" {
+		t.Errorf("Expected non-empty host field, got %v", entry["host"])
+	}
+	if pid, _ := entry["pid"].(float64); int(pid) != os.Getpid() {
+		t.Errorf("Expected pid %d, got %v", os.Getpid(), entry["pid"])
+	}
+}
+
+// newBufferedZap builds a zapLogger from conf that writes into the returned buffer
+func newBufferedZap(t *testing.T, conf *Config, level LogLevel) (*zapLogger, *bytes.Buffer) {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	logger, err := buildZap(conf, level, zapcore.AddSync(buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return logger, buf
+}
+
+// decodeEntry parses the first JSON log line written to buf
+func decodeEntry(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+	t.Helper()
+	line, _, _ := strings.Cut(buf.String(), "\n")
+	entry := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(line), &entry); err != nil {
+		t.Fatalf("Failed to decode log entry %q: %v", line, err)
+	}
+	return entry
+}
+
+// decodeEntries parses every JSON log line in out
+func decodeEntries(t *testing.T, out string) []map[string]interface{} {
+	t.Helper()
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		entry := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Failed to decode log entry %q: %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
 // Helper function to check if a substring exists in a string
 func contains(str, substr string) bool {
 	return strings.Contains(str, substr)
 }
+
+// Test Clock to verify a frozen clock produces a known timestamp
+func TestConfigClock(t *testing.T) {
+	frozen := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	logger, buf := newBufferedZap(t, &Config{IsJson: true, Clock: func() time.Time { return frozen }}, InfoLevel)
+	logger.Info("tick")
+
+	entry := decodeEntry(t, buf)
+	if want := "2024-03-01T12:30:00.000Z"; entry["timestamp"] != want {
+		t.Errorf("Expected timestamp %q, got %v", want, entry["timestamp"])
+	}
+}
+
+// Test Infom and Errorm to verify the returned string equals the logged message
+func TestZapLogger_Infom(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	infoMsg := logger.Infom("user %s not found", "bob")
+	errMsg := logger.Errorm("100%% failed")
+
+	entries := decodeEntries(t, buf.String())
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	if infoMsg != "user bob not found" || entries[0]["message"] != infoMsg {
+		t.Errorf("Infom returned %q, logged %v", infoMsg, entries[0]["message"])
+	}
+	if entries[1]["severity"] != "error" || entries[1]["message"] != errMsg {
+		t.Errorf("Errorm returned %q, logged %v", errMsg, entries[1])
+	}
+}
+
+// Test InfoMap and ErrorMap to verify map entries and pre-attached With fields both appear
+func TestZapLogger_InfoMap(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	scoped := logger.With("request_id", "r-1")
+	scoped.InfoMap("response", map[string]interface{}{"status": 200, "bytes": 512})
+	scoped.ErrorMap("failed", map[string]interface{}{"status": 500})
+
+	entries := decodeEntries(t, buf.String())
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	if e := entries[0]; e["request_id"] != "r-1" || e["status"] != float64(200) || e["bytes"] != float64(512) {
+		t.Errorf("Expected context and map fields, got %v", e)
+	}
+	if e := entries[1]; e["severity"] != "error" || e["request_id"] != "r-1" || e["status"] != float64(500) {
+		t.Errorf("Expected an error entry with context and map fields, got %v", e)
+	}
+}
+
+// Test WarnOnce to verify repeated keys are logged once across loggers and distinct keys are not suppressed
+func TestZapLogger_WarnOnce(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	deprecated, other := t.Name()+".deprecated", t.Name()+".other"
+	t.Cleanup(func() {
+		warnedKeys.Delete(deprecated)
+		warnedKeys.Delete(other)
+	})
+	logger.WarnOnce(deprecated, "Config.Foo is deprecated")
+	logger.WithField("k", "v").WarnOnce(deprecated, "Config.Foo is deprecated")
+	logger.WarnOnce(other, "configure Bar")
+
+	entries := decodeEntries(t, buf.String())
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d: %s", len(entries), buf.String())
+	}
+	if entries[0]["severity"] != "warn" || entries[0]["message"] != "Config.Foo is deprecated" {
+		t.Errorf("Expected the first warning, got %v", entries[0])
+	}
+	if entries[1]["message"] != "configure Bar" {
+		t.Errorf("Expected the distinct key to be logged, got %v", entries[1])
+	}
+}
+
+// Test LevelColors to verify custom color sequences replace the defaults only for configured levels
+func TestLevelColors(t *testing.T) {
+	conf := &Config{LevelColors: map[LogLevel]string{ErrorLevel: "1;31", InfoLevel: ""}}
+	logger, buf := newBufferedZap(t, conf, DebugLevel)
+	logger.Error("failed")
+	logger.Info("started")
+	logger.Warn("slow")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got %q", buf.String())
+	}
+	if !strings.HasPrefix(lines[0], "\x1b[1;31mERROR\x1b[0m") {
+		t.Errorf("Expected custom error color, got %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "INFO") {
+		t.Errorf("Expected uncolored info level, got %q", lines[1])
+	}
+	if !strings.HasPrefix(lines[2], "\x1b[33mWARN\x1b[0m") {
+		t.Errorf("Expected default warn color, got %q", lines[2])
+	}
+}
+
+// Test LevelToken to verify console lines carry a level= token matching the colored label
+func TestLevelToken(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{LevelToken: true}, TraceLevel)
+	logger.Warn("slow")
+	logger.Trace("step")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %q", buf.String())
+	}
+	if !strings.HasPrefix(lines[0], "\x1b[33mWARN\x1b[0m\tlevel=warn\t") {
+		t.Errorf("Expected the colored label followed by level=warn, got %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "\tlevel=trace\t") {
+		t.Errorf("Expected level=trace, got %q", lines[1])
+	}
+}
+
+// Test EncodingPlain to verify console lines carry timestamp, level, caller and message without escapes
+func TestEncodingPlain(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{Encoding: EncodingPlain, IsJson: true}, InfoLevel)
+	logger.Warn("disk almost full")
+
+	line := strings.TrimSpace(buf.String())
+	if strings.Contains(line, "\x1b[") {
+		t.Errorf("Expected no ANSI escapes, got %q", line)
+	}
+	parts := strings.Split(line, "\t")
+	if len(parts) != 4 {
+		t.Fatalf("Expected timestamp, level, caller and message columns, got %q", line)
+	}
+	if _, err := time.Parse("2006-01-02T15:04:05.000Z0700", parts[0]); err != nil {
+		t.Errorf("Expected a timestamp column, got %q", parts[0])
+	}
+	if parts[1] != "WARN" || !strings.Contains(parts[2], "zap_test.go:") || parts[3] != "disk almost full" {
+		t.Errorf("Unexpected plain columns: %q", parts)
+	}
+}
+
+// Test WithValidated to verify malformed pairs are rejected and valid pairs are attached
+func TestZapLogger_WithValidated(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	if _, err := logger.WithValidated("user", "bob", "orphan"); err == nil {
+		t.Error("Expected an error for an odd number of arguments")
+	}
+	if _, err := logger.WithValidated(42, "value"); err == nil {
+		t.Error("Expected an error for a non-string key")
+	}
+
+	validated, err := logger.WithValidated("user", "bob", "attempt", 2)
+	if err != nil {
+		t.Fatalf("Expected valid pairs to be accepted, got %v", err)
+	}
+	validated.Info("login")
+
+	entry := decodeEntry(t, buf)
+	if entry["user"] != "bob" || entry["attempt"] != float64(2) {
+		t.Errorf("Expected user and attempt fields, got %v", entry)
+	}
+}
+
+// Test WithFieldIf to verify the field is attached only when the condition holds
+func TestZapLogger_WithFieldIf(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	if l := logger.WithFieldIf(false, "skipped", "x"); l != Logger(logger) {
+		t.Error("Expected the same logger when the condition is false")
+	}
+	logger.WithFieldIf(true, "tenant", "acme").WithFieldIf(false, "skipped", "x").Info("conditional")
+
+	entry := decodeEntry(t, buf)
+	if entry["tenant"] != "acme" {
+		t.Errorf("Expected tenant field, got %v", entry)
+	}
+	if _, ok := entry["skipped"]; ok {
+		t.Errorf("Expected no skipped field, got %v", entry)
+	}
+}
+
+// Benchmark Infow with three fields to track allocations of the pooled field buffers
+func BenchmarkZapLogger_Infow(b *testing.B) {
+	logger, err := buildZap(&Config{IsJson: true}, InfoLevel, zapcore.AddSync(io.Discard))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.Infow("request", "method", "GET", "status", 200, "path", "/users")
+	}
+}
+
+// formatCounter is a fmt.Stringer counting how often it is formatted
+type formatCounter struct{ calls *int }
+
+func (c formatCounter) String() string {
+	*c.calls++
+	return "formatted"
+}
+
+// Test the f-variants to verify disabled levels skip formatting their arguments
+func TestZapLogger_DisabledSkipsFormatting(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, WarnLevel)
+	calls := 0
+	arg := formatCounter{&calls}
+
+	logger.Debugf("value %s", arg)
+	logger.Infof("value %s", arg)
+	logger.Debugw("value", "arg", arg)
+	if calls != 0 || buf.Len() != 0 {
+		t.Errorf("Expected no formatting or output for disabled levels, got %d calls and %q", calls, buf.String())
+	}
+
+	logger.Warnf("value %s", arg)
+	if calls != 1 {
+		t.Errorf("Expected enabled levels to format once, got %d calls", calls)
+	}
+}
+
+// Benchmark Debugf at a disabled level to verify the call returns before any formatting work
+func BenchmarkZapLogger_DebugfDisabled(b *testing.B) {
+	logger, err := buildZap(&Config{IsJson: true}, InfoLevel, zapcore.AddSync(io.Discard))
+	if err != nil {
+		b.Fatal(err)
+	}
+	calls := 0
+	arg := formatCounter{&calls}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.Debugf("value %s", arg)
+	}
+	if calls != 0 {
+		b.Fatalf("Expected no formatting, got %d calls", calls)
+	}
+}
+
+// Test the w-variants to verify pooled fields are written, the caller is the call site and malformed pairs still log
+func TestZapLogger_InfowPooled(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	derived := logger.WithField("service", "api")
+	derived.Infow("first", "status", 200)
+	derived.Warnw("second", "status", 503)
+	derived.Errorw("odd", "orphan")
+
+	entries := decodeEntries(t, buf.String())
+	if len(entries) < 3 {
+		t.Fatalf("Expected at least 3 entries, got %v", entries)
+	}
+	if entries[0]["status"] != float64(200) || entries[1]["status"] != float64(503) || entries[1]["service"] != "api" {
+		t.Errorf("Unexpected fields: %v", entries[:2])
+	}
+	for _, entry := range entries {
+		if entry["message"] == "Ignored key without a value." {
+			continue // Reported by zap itself for the malformed pair.
+		}
+		if module, _ := entry["module"].(string); !strings.Contains(module, "zap_test.go") {
+			t.Errorf("Expected the caller to be the test file, got %v", entry["module"])
+		}
+	}
+}
+
+// Test DurationEncoder to verify durations follow the configured format
+func TestDurationEncoder(t *testing.T) {
+	tests := []struct {
+		format DurationFormat
+		want   interface{}
+	}{
+		{"", "1.5s"},
+		{DurationSeconds, 1.5},
+		{DurationMillis, float64(1500)},
+		{DurationNanos, float64(1500000000)},
+	}
+	for _, tt := range tests {
+		logger, buf := newBufferedZap(t, &Config{IsJson: true, DurationEncoder: tt.format}, InfoLevel)
+		logger.Infow("done", "took", 1500*time.Millisecond)
+		if got := decodeEntry(t, buf)["took"]; got != tt.want {
+			t.Errorf("DurationEncoder %q rendered %v; want %v", tt.format, got, tt.want)
+		}
+	}
+}
+
+// codedError is an error carrying a code for dashboards
+type codedError struct{ code string }
+
+func (e codedError) Error() string { return "payment declined" }
+func (e codedError) Code() string  { return e.code }
+
+// Test WithCodedError to verify coded errors add error_code while plain errors only add error
+func TestZapLogger_WithCodedError(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	logger.WithCodedError(fmt.Errorf("charge: %w", codedError{"E402"})).Error("coded")
+	logger.WithCodedError(errors.New("timeout")).Error("plain")
+
+	entries := decodeEntries(t, buf.String())
+	if entries[0]["error"] != "charge: payment declined" || entries[0]["error_code"] != "E402" {
+		t.Errorf("Expected error and error_code fields, got %v", entries[0])
+	}
+	if _, ok := entries[1]["error_code"]; ok || entries[1]["error"] != "timeout" {
+		t.Errorf("Expected only the error field, got %v", entries[1])
+	}
+}
+
+// Test WithErrorChain to verify the message, type and unwrapped chain of a wrapped error are attached
+func TestZapLogger_WithErrorChain(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	root := errors.New("connection refused")
+	err := fmt.Errorf("charge: %w", fmt.Errorf("dial: %w", root))
+	logger.WithErrorChain(err).Error("failed")
+	logger.WithErrorChain(nil).Info("no error")
+
+	entries := decodeEntries(t, buf.String())
+	if entries[0]["error"] != "charge: dial: connection refused" || entries[0]["error_type"] != "*fmt.wrapError" {
+		t.Errorf("Expected error and error_type fields, got %v", entries[0])
+	}
+	want := []interface{}{"charge: dial: connection refused", "dial: connection refused", "connection refused"}
+	if !reflect.DeepEqual(entries[0]["error_chain"], want) {
+		t.Errorf("Expected error_chain %v, got %v", want, entries[0]["error_chain"])
+	}
+	if _, ok := entries[1]["error"]; ok {
+		t.Errorf("Expected no error fields for nil, got %v", entries[1])
+	}
+}
+
+// Test Unwrap to verify the raw zap logger carries the context fields and reports its own caller
+func TestZapLogger_Unwrap(t *testing.T) {
+	logger, buf := newBufferedZap(t, &Config{IsJson: true}, InfoLevel)
+	var l Logger = logger.With("request_id", "r-1")
+	unwrapper, ok := l.(ZapUnwrapper)
+	if !ok {
+		t.Fatal("Expected the logger to implement ZapUnwrapper")
+	}
+
+	unwrapper.Unwrap().Info("raw", zap.Int("attempt", 2))
+
+	entry := decodeEntry(t, buf)
+	if entry["request_id"] != "r-1" || entry["attempt"] != float64(2) {
+		t.Errorf("Expected the context field and the zap field, got %v", entry)
+	}
+	if module, _ := entry["module"].(string); !strings.Contains(module, "zap_test.go") {
+		t.Errorf("Expected the test file as caller, got %q", module)
+	}
+}